@@ -0,0 +1,240 @@
+package go_clipper2
+
+import (
+	"math"
+	"slices"
+)
+
+// ClipLinesPaths64 clips the open paths lines against the closed paths clip,
+// returning the parts of lines inside the region clip fills under fillRule,
+// and the parts outside it.
+func ClipLinesPaths64(lines, clip Paths64, fillRule FillRule) (inside, outside Paths64) {
+	return clipLines64(Intersection, lines, clip, fillRule), clipLines64(Difference, lines, clip, fillRule)
+}
+
+// ClipLinesPathsD is ClipLinesPaths64 for PathsD, with coordinates rounded to
+// precisionV decimal places (2 by default).
+func ClipLinesPathsD(lines, clip PathsD, fillRule FillRule, precisionV ...int) (inside, outside PathsD) {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+	checkPrecision(precision)
+	scale := math.Pow(10, float64(precision))
+
+	inside64, outside64 := ClipLinesPaths64(ScalePathsDToPaths64(lines, scale), ScalePathsDToPaths64(clip, scale), fillRule)
+	return ScalePaths64ToPathsD(inside64, 1/scale), ScalePaths64ToPathsD(outside64, 1/scale)
+}
+
+func clipLines64(clipType ClipType, lines, clip Paths64, fillRule FillRule) Paths64 {
+	solutionClosed, solutionOpen := make(Paths64, 0), make(Paths64, 0)
+	if len(lines) == 0 {
+		return solutionOpen
+	}
+
+	c := NewClipper64()
+	c.AddPaths(lines, Subject, true)
+	c.AddPaths(clip, Clip, false)
+	c.ExecuteOC(clipType, fillRule, &solutionClosed, &solutionOpen)
+	return solutionOpen
+}
+
+// TaggedPath64 is a part of a polyline with its location relative to a filled
+// region: IsInside, IsOutside, or IsOn when it runs along the region's boundary.
+type TaggedPath64 struct {
+	Path     Path64
+	Location PointInPolygonResult
+}
+
+type TaggedPathD struct {
+	Path     PathD
+	Location PointInPolygonResult
+}
+
+// SplitLinesByPolygons64 cuts the open paths lines wherever they cross or
+// touch the edges of polygons and returns the pieces in order, tagged by
+// where they lie relative to the region polygons fill under fillRule.
+// Consecutive pieces with the same location are joined. Edges that aren't on
+// the region's boundary, such as shared edges of polygons that are unioned
+// by fillRule, don't count as boundary.
+func SplitLinesByPolygons64(lines, polygons Paths64, fillRule FillRule) []TaggedPath64 {
+	// the edges are indexed one per path, so each segment and each location
+	// test only visits the edges near it
+	edgePaths := make(Paths64, 0)
+	right := int64(math.MinInt64)
+	for _, path := range polygons {
+		if len(path) < 3 {
+			continue
+		}
+		prev := path[len(path)-1]
+		for _, pt := range path {
+			if pt != prev {
+				edgePaths = append(edgePaths, Path64{prev, pt})
+			}
+			right = max(right, pt.X)
+			prev = pt
+		}
+	}
+	edges := &lineEdges64{index: NewPathsIndex64(edgePaths), right: right}
+
+	result := make([]TaggedPath64, 0)
+	for _, line := range lines {
+		var curr TaggedPath64
+		for i := 1; i < len(line); i++ {
+			a, b := line[i-1], line[i]
+			if a == b {
+				continue
+			}
+
+			ts := lineSplits64(a, b, edges.near(GetBounds64(Path64{a, b})))
+			for j := 1; j < len(ts); j++ {
+				start, end := lerpPoint64(a, b, ts[j-1]), lerpPoint64(a, b, ts[j])
+				if start == end {
+					continue
+				}
+				loc := lineLocation64(a, b, ts[j-1], ts[j], edges, fillRule)
+				if curr.Path == nil || curr.Location != loc {
+					if curr.Path != nil {
+						result = append(result, curr)
+					}
+					curr = TaggedPath64{Path: Path64{start}, Location: loc}
+				}
+				curr.Path = append(curr.Path, end)
+			}
+		}
+		if curr.Path != nil {
+			result = append(result, curr)
+		}
+	}
+	return result
+}
+
+// SplitLinesByPolygonsD is SplitLinesByPolygons64 for PathsD, with
+// coordinates rounded to precisionV decimal places (2 by default).
+func SplitLinesByPolygonsD(lines, polygons PathsD, fillRule FillRule, precisionV ...int) []TaggedPathD {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+	checkPrecision(precision)
+	scale := math.Pow(10, float64(precision))
+
+	tagged := SplitLinesByPolygons64(ScalePathsDToPaths64(lines, scale), ScalePathsDToPaths64(polygons, scale), fillRule)
+	result := make([]TaggedPathD, len(tagged))
+	for i, tp := range tagged {
+		result[i] = TaggedPathD{Path: ScalePath64ToPathD(tp.Path, 1/scale), Location: tp.Location}
+	}
+	return result
+}
+
+// lineSplits64 returns, in order, the parameters along a->b (0 and 1
+// included) at which it crosses or touches an edge, or at which an edge
+// running along it starts or ends. edges only need to hold those whose bounds
+// meet the segment's.
+func lineSplits64(a, b Point64, edges []edge64) []float64 {
+	result := []float64{0, 1}
+	dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+	for _, e := range edges {
+		d1, d2 := CrossProduct(a, b, e.a), CrossProduct(a, b, e.b)
+		if d1 == 0 && d2 == 0 {
+			for _, pt := range [2]Point64{e.a, e.b} {
+				t := (float64(pt.X-a.X)*dx + float64(pt.Y-a.Y)*dy) / (dx*dx + dy*dy)
+				if t > 0 && t < 1 {
+					result = append(result, t)
+				}
+			}
+			continue
+		}
+		if (d1 > 0 && d2 > 0) || (d1 < 0 && d2 < 0) {
+			continue
+		}
+
+		d3, d4 := CrossProduct(e.a, e.b, a), CrossProduct(e.a, e.b, b)
+		if (d3 > 0 && d4 > 0) || (d3 < 0 && d4 < 0) {
+			continue
+		}
+		if t := d3 / (d3 - d4); t > 0 && t < 1 {
+			result = append(result, t)
+		}
+	}
+
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+func lerpPoint64(a, b Point64, t float64) Point64 {
+	switch t {
+	case 0:
+		return a
+	case 1:
+		return b
+	}
+	return NewFloatPoint64(float64(a.X)+t*float64(b.X-a.X), float64(a.Y)+t*float64(b.Y-a.Y))
+}
+
+// lineLocation64 locates the piece of a->b between t0 and t1, which crosses
+// no edge, by testing a point just either side of its middle: the piece is on
+// the boundary if exactly one of them is filled.
+func lineLocation64(a, b Point64, t0, t1 float64, edges *lineEdges64, fillRule FillRule) PointInPolygonResult {
+	dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+	length := math.Hypot(dx, dy)
+	t := (t0 + t1) / 2
+	mx, my := float64(a.X)+t*dx, float64(a.Y)+t*dy
+
+	eps := 1e-3 * min(1, (t1-t0)*length)
+	nx, ny := -dy/length*eps, dx/length*eps
+	left := isFilled(edges.windingNumberAt(mx+nx, my+ny), fillRule)
+	right := isFilled(edges.windingNumberAt(mx-nx, my-ny), fillRule)
+	switch {
+	case left && right:
+		return IsInside
+	case left || right:
+		return IsOn
+	}
+	return IsOutside
+}
+
+// lineEdges64 holds the polygon edges of SplitLinesByPolygons64, each indexed
+// as a two point path, and their rightmost X.
+type lineEdges64 struct {
+	index *PathsIndex64
+	right int64
+}
+
+// near returns the edges whose bounds meet rect.
+func (le *lineEdges64) near(rect Rect64) []edge64 {
+	found := le.index.Query(rect)
+	result := make([]edge64, len(found))
+	for i, j := range found {
+		path := le.index.Path(j)
+		result[i] = edge64{path[0], path[1]}
+	}
+	return result
+}
+
+// windingNumberAt is windingNumberAt over the edges that may cross the ray
+// from (x, y) to the right, which are the only ones it counts.
+func (le *lineEdges64) windingNumberAt(x, y float64) int {
+	left, top := int64(math.Floor(x)), int64(math.Floor(y))
+	ray := NewRect64(left, top, max(left, le.right), int64(math.Ceil(y)))
+	return windingNumberAt(x, y, le.near(ray))
+}
+
+// windingNumberAt is the winding number of edges around the point (x, y),
+// which is assumed not to be on any of them.
+func windingNumberAt(x, y float64, edges []edge64) int {
+	result := 0
+	for _, e := range edges {
+		ay, by := float64(e.a.Y), float64(e.b.Y)
+		if (ay > y) == (by > y) {
+			continue
+		}
+		d := float64(e.b.X-e.a.X)*(y-ay) - (by-ay)*(x-float64(e.a.X))
+		if ay <= y && d > 0 {
+			result++
+		} else if ay > y && d < 0 {
+			result--
+		}
+	}
+	return result
+}
@@ -0,0 +1,170 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClipLinesPaths64(t *testing.T) {
+	// a square with a square hole
+	clip := goclipper2.Paths64{
+		goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+		goclipper2.MakePath64(40, 40, 40, 60, 60, 60, 60, 40),
+	}
+	lines := goclipper2.Paths64{goclipper2.MakePath64(-50, 50, 150, 50)}
+
+	inside, outside := goclipper2.ClipLinesPaths64(lines, clip, goclipper2.NonZero)
+	assert.Equal(t, 2, len(inside))
+	assert.Equal(t, 3, len(outside))
+	assert.InDelta(t, 80, linesLength64(inside), 1e-9)
+	assert.InDelta(t, 120, linesLength64(outside), 1e-9)
+
+	inside, outside = goclipper2.ClipLinesPaths64(nil, clip, goclipper2.NonZero)
+	assert.Equal(t, 0, len(inside))
+	assert.Equal(t, 0, len(outside))
+}
+
+func TestClipLinesPathsD(t *testing.T) {
+	clip := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 0, 1)}
+	lines := goclipper2.PathsD{goclipper2.MakePathD(0.5, -1, 0.5, 0.5, 2, 0.5)}
+
+	inside, outside := goclipper2.ClipLinesPathsD(lines, clip, goclipper2.NonZero)
+	assert.Equal(t, 1, len(inside))
+	assert.Equal(t, 2, len(outside))
+	assert.ElementsMatch(t, goclipper2.PathD{{X: 0.5, Y: 0}, {X: 0.5, Y: 0.5}, {X: 1, Y: 0.5}}, inside[0])
+}
+
+func TestSplitLinesByPolygons64(t *testing.T) {
+	var (
+		square = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}
+		// two squares sharing the edge x = 100
+		adjacent = goclipper2.Paths64{
+			goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+			goclipper2.MakePath64(100, 0, 200, 0, 200, 100, 100, 100),
+		}
+		withHole = goclipper2.Paths64{
+			goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+			goclipper2.MakePath64(25, 25, 25, 75, 75, 75, 75, 25),
+		}
+	)
+
+	in, out, on := goclipper2.IsInside, goclipper2.IsOutside, goclipper2.IsOn
+	tests := []struct {
+		name     string
+		lines    goclipper2.Paths64
+		polygons goclipper2.Paths64
+		fillRule goclipper2.FillRule
+		expect   []goclipper2.TaggedPath64
+	}{
+		{
+			name:     "crossing",
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(-50, 50, 150, 50)},
+			polygons: square,
+			fillRule: goclipper2.NonZero,
+			expect: []goclipper2.TaggedPath64{
+				{Path: goclipper2.MakePath64(-50, 50, 0, 50), Location: out},
+				{Path: goclipper2.MakePath64(0, 50, 100, 50), Location: in},
+				{Path: goclipper2.MakePath64(100, 50, 150, 50), Location: out},
+			},
+		},
+		{
+			name:     "along an edge",
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(-50, 0, 50, 0, 50, 50)},
+			polygons: square,
+			fillRule: goclipper2.NonZero,
+			expect: []goclipper2.TaggedPath64{
+				{Path: goclipper2.MakePath64(-50, 0, 0, 0), Location: out},
+				{Path: goclipper2.MakePath64(0, 0, 50, 0), Location: on},
+				{Path: goclipper2.MakePath64(50, 0, 50, 50), Location: in},
+			},
+		},
+		{
+			name:     "shared edge",
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(100, -50, 100, 50)},
+			polygons: adjacent,
+			fillRule: goclipper2.NonZero,
+			expect: []goclipper2.TaggedPath64{
+				{Path: goclipper2.MakePath64(100, -50, 100, 0), Location: out},
+				{Path: goclipper2.MakePath64(100, 0, 100, 50), Location: in},
+			},
+		},
+		{
+			name:     "hole",
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(10, 50, 50, 50, 50, 90)},
+			polygons: withHole,
+			fillRule: goclipper2.EvenOdd,
+			expect: []goclipper2.TaggedPath64{
+				{Path: goclipper2.MakePath64(10, 50, 25, 50), Location: in},
+				{Path: goclipper2.MakePath64(25, 50, 50, 50, 50, 75), Location: out},
+				{Path: goclipper2.MakePath64(50, 75, 50, 90), Location: in},
+			},
+		},
+		{
+			name:     "touching a vertex",
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(-50, 50, 0, 100, -50, 150)},
+			polygons: square,
+			fillRule: goclipper2.NonZero,
+			expect: []goclipper2.TaggedPath64{
+				{Path: goclipper2.MakePath64(-50, 50, 0, 100, -50, 150), Location: out},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			assert.Equal(t, tt.expect, goclipper2.SplitLinesByPolygons64(tt.lines, tt.polygons, tt.fillRule))
+		})
+	}
+}
+
+func TestSplitLinesByPolygonsD(t *testing.T) {
+	polygons := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 0, 1)}
+	lines := goclipper2.PathsD{goclipper2.MakePathD(-0.5, 0.25, 0.5, 0.25)}
+
+	result := goclipper2.SplitLinesByPolygonsD(lines, polygons, goclipper2.NonZero)
+	assert.Equal(t, []goclipper2.TaggedPathD{
+		{Path: goclipper2.MakePathD(-0.5, 0.25, 0, 0.25), Location: goclipper2.IsOutside},
+		{Path: goclipper2.MakePathD(0, 0.25, 0.5, 0.25), Location: goclipper2.IsInside},
+	}, result)
+}
+
+func TestSplitLinesByPolygons64Large(t *testing.T) {
+	// many lines across a polygon with many edges: the inside pieces add up to
+	// what the clipping engine keeps
+	polygons := goclipper2.Paths64{
+		goclipper2.Ellipse64(goclipper2.Point64{X: 0, Y: 0}, 100000, 100000, 4000),
+		goclipper2.Ellipse64(goclipper2.Point64{X: 0, Y: 0}, 50000, 50000, 2000),
+	}
+	lines := make(goclipper2.Paths64, 0)
+	for y := int64(-110000); y <= 110000; y += 1000 {
+		lines = append(lines, goclipper2.MakePath64(-110000, y+7, 110000, y+7))
+	}
+
+	result := goclipper2.SplitLinesByPolygons64(lines, polygons, goclipper2.EvenOdd)
+	insidePaths, outsidePaths := make(goclipper2.Paths64, 0), make(goclipper2.Paths64, 0)
+	for _, tp := range result {
+		if tp.Location == goclipper2.IsInside {
+			insidePaths = append(insidePaths, tp.Path)
+		} else {
+			outsidePaths = append(outsidePaths, tp.Path)
+		}
+	}
+
+	inside, outside := goclipper2.ClipLinesPaths64(lines, polygons, goclipper2.EvenOdd)
+	assert.InDelta(t, linesLength64(inside), linesLength64(insidePaths), 1)
+	assert.InDelta(t, linesLength64(outside), linesLength64(outsidePaths), 1)
+}
+
+func linesLength64(paths goclipper2.Paths64) float64 {
+	result := 0.0
+	for _, path := range paths {
+		for i := 1; i < len(path); i++ {
+			result += math.Hypot(float64(path[i].X-path[i-1].X), float64(path[i].Y-path[i-1].Y))
+		}
+	}
+	return result
+}
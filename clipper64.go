@@ -16,15 +16,16 @@ func (c *clipper64) Execute(clipType ClipType, fillRule FillRule, solution *Path
 	return c.ExecuteOC(clipType, fillRule, solution, &solOpen)
 }
 
-func (c *clipper64) ExecutePolyTree64(clipType ClipType, fillRule FillRule, polytree *PolyTree64, openPaths *PathsD) bool {
+// ExecutePolyTree64 builds the closed solution into polytree and returns the
+// clipped open paths, if any were added, in openPaths.
+func (c *clipper64) ExecutePolyTree64(clipType ClipType, fillRule FillRule, polytree *PolyTree64, openPaths *Paths64) bool {
 	c.usingPolyTree = true
 
 	polytree.Clear()
 	*openPaths = (*openPaths)[:0]
-	oPaths := make(Paths64, 0)
 
 	c.clipperBase.executeInternal(clipType, fillRule)
-	c.buildTree(polytree.PolyPathBase, &oPaths)
+	c.buildTree(polytree.PolyPathBase, openPaths)
 
 	c.clearSolutionOnly()
 	return c.succeeded
@@ -88,7 +89,26 @@ func BooleanOpPolyTree64(clipType ClipType, subject Paths64, clip Paths64, fillR
 		c.AddPaths(clip, Clip, false)
 	}
 
-	openPath := make(PathsD, 0)
-	c.ExecutePolyTree64(clipType, fillRule, polytree, &openPath)
+	openPaths := make(Paths64, 0)
+	c.ExecutePolyTree64(clipType, fillRule, polytree, &openPaths)
 	return polytree
 }
+
+// BooleanOpPolyTreeWithOpen64 is BooleanOpPolyTree64 for subjects that mix
+// closed paths with open paths (polylines), returning the clipped open paths
+// alongside the tree of closed ones.
+func BooleanOpPolyTreeWithOpen64(clipType ClipType, subject, subjectOpen, clip Paths64, fillRule FillRule) (*PolyTree64, Paths64) {
+	polytree := NewPolyTree64()
+	c := NewClipper64()
+	c.AddPaths(subject, Subject, false)
+	if len(subjectOpen) > 0 {
+		c.AddPaths(subjectOpen, Subject, true)
+	}
+	if clip != nil {
+		c.AddPaths(clip, Clip, false)
+	}
+
+	openPaths := make(Paths64, 0)
+	c.ExecutePolyTree64(clipType, fillRule, polytree, &openPaths)
+	return polytree, openPaths
+}
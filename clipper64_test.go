@@ -2,6 +2,7 @@ package go_clipper2_test
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 
@@ -107,6 +108,40 @@ func TestBooleanOpPaths64(t *testing.T) {
 	}
 }
 
+func TestBooleanOpPaths64SelfIntersecting(t *testing.T) {
+	// {803 1137} is just across the edge {768 1115}-{925 1214}, so both
+	// crossings round onto it and the path becomes two touching loops
+	subject := goclipper2.Paths64{goclipper2.MakePath64(915, 1221, 803, 1137, 512, 1094, 768, 960, 768, 1115, 925, 1214)}
+
+	results := goclipper2.UnionPaths64(subject, goclipper2.NonZero)
+	assert.Equal(t, 2, len(results))
+	assert.InDelta(t, goclipper2.AreaPaths64(subject), goclipper2.AreaPaths64(results), 10)
+
+	// both loops survive a clip that covers them, and come out as two
+	// outer polygons of a tree
+	bounds := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 2000, 0, 2000, 2000, 0, 2000)}
+	results = goclipper2.IntersectWithClipPaths64(subject, bounds, goclipper2.NonZero)
+	assert.Equal(t, 2, len(results))
+	assert.InDelta(t, goclipper2.AreaPaths64(subject), goclipper2.AreaPaths64(results), 10)
+
+	polytree := goclipper2.BooleanOpPolyTree64(goclipper2.Union, subject, nil, goclipper2.NonZero)
+	assert.Equal(t, 2, len(polytree.GetChildren()))
+	for _, child := range polytree.GetChildren() {
+		assert.Equal(t, 0, len(child.GetChildren()))
+		assert.True(t, goclipper2.IsPositive64(child.Polygon()) == goclipper2.IsPositive64(results[0]))
+	}
+}
+
+func TestBooleanOpPaths64LargeCoords(t *testing.T) {
+	// the edge into {100000 1300000} is nearly horizontal, so its join
+	// check measures a distance whose square overflows int64
+	subject := goclipper2.Paths64{goclipper2.MakePath64(100000, 1300000, 50000, 0, 300000, 1300001)}
+
+	results := goclipper2.UnionPaths64(subject, goclipper2.NonZero)
+	assert.Equal(t, 1, len(results))
+	assert.InDelta(t, math.Abs(goclipper2.AreaPaths64(subject)), math.Abs(goclipper2.AreaPaths64(results)), 1)
+}
+
 func TestPolyTree64(t *testing.T) {
 	subject := make(goclipper2.Paths64, 0)
 	subject = append(subject, goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100))
@@ -134,3 +169,57 @@ func TestPolyTree64(t *testing.T) {
 			  +- polygon (1) contains 2 holes
 	*/
 }
+
+func TestPolyTreeWithOpen64(t *testing.T) {
+	subject := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}
+	subjectOpen := goclipper2.Paths64{
+		goclipper2.MakePath64(-50, 50, 150, 50),
+		goclipper2.MakePath64(10, -50, 10, 150),
+	}
+	clip := goclipper2.Paths64{goclipper2.MakePath64(20, 20, 80, 20, 80, 80, 20, 80)}
+
+	polytree, open := goclipper2.BooleanOpPolyTreeWithOpen64(goclipper2.Difference, subject, subjectOpen, clip, goclipper2.NonZero)
+	assert.Equal(t, 1, len(polytree.GetChildren()))
+	assert.Equal(t, 1, len(polytree.GetChildren()[0].GetChildren()))
+	assert.ElementsMatch(t, goclipper2.Paths64{
+		goclipper2.MakePath64(-50, 50, 20, 50),
+		goclipper2.MakePath64(80, 50, 150, 50),
+		goclipper2.MakePath64(10, -50, 10, 150),
+	}, normalizeOpenPaths64(open))
+
+	// the open paths match those of ExecuteOC
+	c := goclipper2.NewClipper64()
+	c.AddPaths(subject, goclipper2.Subject, false)
+	c.AddPaths(subjectOpen, goclipper2.Subject, true)
+	c.AddPaths(clip, goclipper2.Clip, false)
+	closed, expect := goclipper2.Paths64{}, goclipper2.Paths64{}
+	c.ExecuteOC(goclipper2.Difference, goclipper2.NonZero, &closed, &expect)
+	assert.ElementsMatch(t, expect, open)
+
+	polytree, open = goclipper2.BooleanOpPolyTreeWithOpen64(goclipper2.Intersection, nil, subjectOpen, clip, goclipper2.NonZero)
+	assert.Equal(t, 0, len(polytree.GetChildren()))
+	assert.ElementsMatch(t, goclipper2.Paths64{goclipper2.MakePath64(20, 50, 80, 50)}, normalizeOpenPaths64(open))
+
+	// a polyline of several vertices stays out of the tree
+	polyline := goclipper2.Paths64{goclipper2.MakePath64(10, 10, 50, 90, 90, 10)}
+	polytree, open = goclipper2.BooleanOpPolyTreeWithOpen64(goclipper2.Intersection, subject, polyline, subject, goclipper2.NonZero)
+	assert.Equal(t, 1, len(polytree.GetChildren()))
+	assert.Equal(t, 0, len(polytree.GetChildren()[0].GetChildren()))
+	assert.InDelta(t, 100*100, goclipper2.Area64(polytree.GetChildren()[0].Polygon()), 0)
+	assert.Equal(t, 1, len(open))
+	assert.Equal(t, 3, len(open[0]))
+}
+
+// normalizeOpenPaths64 orders the ends of every open path so the smaller
+// point comes first.
+func normalizeOpenPaths64(paths goclipper2.Paths64) goclipper2.Paths64 {
+	result := goclipper2.Paths64{}
+	for _, path := range paths {
+		first, last := path[0], path[len(path)-1]
+		if last.X < first.X || (last.X == first.X && last.Y < first.Y) {
+			path = goclipper2.ReversePath(path)
+		}
+		result = append(result, path)
+	}
+	return result
+}
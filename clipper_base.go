@@ -95,6 +95,7 @@ func (c *clipperBase) buildTree(polytree *PolyPathBase, solutionOpen *Paths64) {
 			if c.buildPath(outrec.pts, c.reverseSolution, true, &openPath) {
 				*solutionOpen = append(*solutionOpen, openPath)
 			}
+			continue
 		}
 		if c.checkBounds(outrec) {
 			c.recursiveCheckOwners(outrec, polytree)
@@ -621,7 +622,7 @@ func (c *clipperBase) doSplitOp(outrec *OutRec, splitOp *OutPt) {
 		prevOp.next = newOp
 	}
 
-	if !(absArea2 > 1) || !(absArea2 > absArea1 && (area2 > 0) != (area1 > 0)) {
+	if !(absArea2 > 1) || (!(absArea2 > absArea1) && (area2 > 0) != (area1 > 0)) {
 		return
 	}
 
@@ -164,3 +164,29 @@ func BooleanOpPolyTreeD(clipType ClipType, subject PathsD, clip PathsD, fillRule
 	c.ExecutePolyTreeD(clipType, fillRule, polytree, &openPath)
 	return polytree
 }
+
+// BooleanOpPolyTreeWithOpenD is BooleanOpPolyTreeWithOpen64 for PathsD. The
+// returned open paths are the pieces of subjectOpen that clipType keeps
+// against the closed paths, e.g. those inside clip for Intersection, as
+// polylines in the units of subject rounded to precisionV decimal places (2 by
+// default); they are not part of the tree.
+func BooleanOpPolyTreeWithOpenD(clipType ClipType, subject, subjectOpen, clip PathsD, fillRule FillRule, precisionV ...int) (*PolyTreeD, PathsD) {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+
+	polytree := NewPolyTreeD()
+	c := NewClipperD(precision)
+	c.AddPaths(subject, Subject, false)
+	if len(subjectOpen) > 0 {
+		c.AddPaths(subjectOpen, Subject, true)
+	}
+	if clip != nil {
+		c.AddPaths(clip, Clip, false)
+	}
+
+	openPaths := make(PathsD, 0)
+	c.ExecutePolyTreeD(clipType, fillRule, polytree, &openPaths)
+	return polytree, openPaths
+}
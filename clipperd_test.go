@@ -107,6 +107,15 @@ func TestBooleanOpPathsD(t *testing.T) {
 	}
 }
 
+func TestBooleanOpPathsDSelfIntersecting(t *testing.T) {
+	// TestBooleanOpPaths64SelfIntersecting's path at a hundredth of the size
+	subject := goclipper2.PathsD{goclipper2.MakePathD(9.15, 12.21, 8.03, 11.37, 5.12, 10.94, 7.68, 9.6, 7.68, 11.15, 9.25, 12.14)}
+
+	results := goclipper2.UnionPathsD(subject, goclipper2.NonZero)
+	assert.Equal(t, 2, len(results))
+	assert.InDelta(t, goclipper2.AreaPathsD(subject), goclipper2.AreaPathsD(results), 1e-3)
+}
+
 func TestPolyTreeD(t *testing.T) {
 	subject := make(goclipper2.PathsD, 0)
 	subject = append(subject, goclipper2.MakePathD(0, 0, 100, 0, 100, 100, 0, 100))
@@ -148,3 +157,22 @@ func TestPolyTreeD(t *testing.T) {
 	hole2 := polytree.GetChildren()[0].GetChildren()[0].GetChildren()[1].GetChildren()[1].Polygon()
 	assert.True(t, reflect.DeepEqual(expected3, hole2), "unexpected second nested polygon")
 }
+
+func TestPolyTreeWithOpenD(t *testing.T) {
+	subject := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 0, 1)}
+	subjectOpen := goclipper2.PathsD{goclipper2.MakePathD(-0.5, 0.25, 1.5, 0.25)}
+
+	polytree, open := goclipper2.BooleanOpPolyTreeWithOpenD(goclipper2.Intersection, subject, subjectOpen, subject, goclipper2.NonZero)
+	assert.Equal(t, 1, len(polytree.Children()))
+	assert.Equal(t, 1, len(open))
+	assert.ElementsMatch(t, goclipper2.PathD{{X: 0, Y: 0.25}, {X: 1, Y: 0.25}}, open[0])
+
+	// a polyline of several vertices stays out of the tree
+	polyline := goclipper2.PathsD{goclipper2.MakePathD(0.1, 0.1, 0.5, 0.9, 0.9, 0.1)}
+	polytree, open = goclipper2.BooleanOpPolyTreeWithOpenD(goclipper2.Intersection, subject, polyline, subject, goclipper2.NonZero)
+	assert.Equal(t, 1, len(polytree.Children()))
+	assert.Equal(t, 0, len(polytree.Children()[0].Children()))
+	assert.InDelta(t, 1, goclipper2.AreaD(polytree.Children()[0].PolygonD()), 1e-9)
+	assert.Equal(t, 1, len(open))
+	assert.Equal(t, 3, len(open[0]))
+}
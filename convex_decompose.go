@@ -0,0 +1,508 @@
+package go_clipper2
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// ConvexDecompose64 splits the region paths fill under fillRule into convex
+// polygons that together cover it exactly, without overlap. Each outer polygon
+// and its holes are triangulated by ear clipping, then neighbouring triangles
+// are merged for as long as the result stays convex (Hertel-Mehlhorn), giving
+// at most four times the minimum number of pieces. The pieces are positively
+// oriented.
+func ConvexDecompose64(paths Paths64, fillRule FillRule) Paths64 {
+	polytree := BooleanOpPolyTree64(Union, paths, nil, fillRule)
+	result := make(Paths64, 0)
+	for outer, holes := range polytree.Outers() {
+		holePaths := make(Paths64, len(holes))
+		for i, hole := range holes {
+			holePaths[i] = hole.Polygon()
+		}
+		result = append(result, mergeConvex64(triangulate64(outer.Polygon(), holePaths))...)
+	}
+	return result
+}
+
+// ConvexDecomposeD is ConvexDecompose64 for PathsD, with coordinates rounded
+// to precisionV decimal places (2 by default).
+func ConvexDecomposeD(paths PathsD, fillRule FillRule, precisionV ...int) PathsD {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+	checkPrecision(precision)
+	scale := math.Pow(10, float64(precision))
+
+	return ScalePaths64ToPathsD(ConvexDecompose64(ScalePathsDToPaths64(paths, scale), fillRule), 1/scale)
+}
+
+// earNode is a vertex of the polygon being ear clipped, in a circular list.
+// Vertices copied by bridges keep the id of the original.
+type earNode struct {
+	pt         Point64
+	id         int
+	prev, next *earNode
+}
+
+func newEarRing(path Path64, positive bool, nextID *int) *earNode {
+	path = StripDuplicates(path, true)
+	if len(path) < 3 {
+		return nil
+	}
+	if IsPositive64(path) != positive {
+		path = ReversePath(path)
+	}
+
+	var first, last *earNode
+	for _, pt := range path {
+		n := &earNode{pt: pt, id: *nextID, prev: last}
+		*nextID++
+		if last == nil {
+			first = n
+		} else {
+			last.next = n
+		}
+		last = n
+	}
+	first.prev, last.next = last, first
+	return first
+}
+
+// triangulate64 returns positively oriented triangles covering outer less
+// holes. Holes are first joined to the outer ring by bridges, making a
+// single ring that touches itself along them.
+func triangulate64(outer Path64, holes Paths64) []Path64 {
+	rings := fixPokes64(append(Paths64{outer}, holes...))
+	outer, holes = rings[0], rings[1:]
+
+	nextID := 0
+	ring := newEarRing(outer, true, &nextID)
+	if ring == nil {
+		return nil
+	}
+
+	// join holes left to right, each to a vertex visible from its leftmost one
+	lefts := make([]*earNode, 0, len(holes))
+	for _, hole := range holes {
+		if h := newEarRing(hole, false, &nextID); h != nil {
+			left := h
+			for n := h.next; n != h; n = n.next {
+				if n.pt.X < left.pt.X || (n.pt.X == left.pt.X && n.pt.Y < left.pt.Y) {
+					left = n
+				}
+			}
+			lefts = append(lefts, left)
+		}
+	}
+	slices.SortFunc(lefts, func(a, b *earNode) int {
+		return cmp.Or(cmp.Compare(a.pt.X, b.pt.X), cmp.Compare(a.pt.Y, b.pt.Y))
+	})
+	for _, left := range lefts {
+		if bridge := findHoleBridge(left, ring); bridge != nil {
+			splitEarRing(bridge, left)
+			if ring = filterEarRing(bridge); ring == nil {
+				return nil
+			}
+		}
+	}
+
+	result := make([]Path64, 0)
+	clipEars(ring, 0, &result)
+	return result
+}
+
+// fixPokes64 inserts each vertex that pokes less than a unit across an edge
+// into that edge, so the rings touch there instead of crossing. Rounding in
+// boolean operations can leave such pokes.
+func fixPokes64(rings Paths64) Paths64 {
+	type ringEdge struct {
+		ring, i int
+		edge64
+	}
+	edges := make([]ringEdge, 0)
+	for r, ring := range rings {
+		for i := range ring {
+			edges = append(edges, ringEdge{r, i, edge64{ring[i], ring[(i+1)%len(ring)]}})
+		}
+	}
+	slices.SortFunc(edges, func(e, f ringEdge) int {
+		return cmp.Compare(min(e.a.X, e.b.X), min(f.a.X, f.b.X))
+	})
+
+	inserts := make(map[[2]int][]Point64)
+	for _, ring := range rings {
+		for i, pt := range ring {
+			prev, next := ring[(i+len(ring)-1)%len(ring)], ring[(i+1)%len(ring)]
+			for _, e := range edges {
+				if min(e.a.X, e.b.X) > pt.X+1 {
+					break
+				}
+				if pt == e.a || pt == e.b || max(e.a.X, e.b.X) < pt.X-1 ||
+					segmentDistanceSqr64(pt, e.a, e.b) > 1 {
+					continue
+				}
+				if segsIntersect(prev, pt, e.a, e.b, false) || segsIntersect(pt, next, e.a, e.b, false) {
+					key := [2]int{e.ring, e.i}
+					inserts[key] = append(inserts[key], pt)
+				}
+			}
+		}
+	}
+	if len(inserts) == 0 {
+		return rings
+	}
+
+	result := make(Paths64, len(rings))
+	for r, ring := range rings {
+		result[r] = make(Path64, 0, len(ring))
+		for i, pt := range ring {
+			result[r] = append(result[r], pt)
+			pts := inserts[[2]int{r, i}]
+			sortAlong64(pts, pt, ring[(i+1)%len(ring)])
+			result[r] = append(result[r], pts...)
+		}
+	}
+	return result
+}
+
+// findHoleBridge returns a vertex of ring that can be joined to the hole
+// vertex h, which is the leftmost of its hole. It looks left from h for the
+// nearest edge, then for the vertex nearest the ray that's in view (Eberly).
+func findHoleBridge(h *earNode, ring *earNode) *earNode {
+	hx, hy := float64(h.pt.X), float64(h.pt.Y)
+	var m *earNode
+	qx := math.Inf(-1)
+
+	n := ring
+	for {
+		a, b := n, n.next
+		if float64(a.pt.Y) >= hy && float64(b.pt.Y) <= hy && b.pt.Y != a.pt.Y {
+			x := float64(a.pt.X) + (hy-float64(a.pt.Y))*float64(b.pt.X-a.pt.X)/float64(b.pt.Y-a.pt.Y)
+			if x <= hx && x > qx {
+				qx = x
+				m = a
+				if b.pt.X < a.pt.X {
+					m = b
+				}
+				if x == hx {
+					// h touches this edge
+					return m
+				}
+			}
+		}
+		n = b
+		if n == ring {
+			break
+		}
+	}
+	if m == nil {
+		return nil
+	}
+
+	// a reflex vertex inside the triangle h, (qx, hy), m would hide m; the
+	// one making the smallest angle with the ray is visible instead
+	stop := m
+	mx, my := float64(m.pt.X), float64(m.pt.Y)
+	tanMin := math.Inf(1)
+	for n = m; ; {
+		px, py := float64(n.pt.X), float64(n.pt.Y)
+		if hx >= px && px >= mx && hx != px && pointInTriangleF(
+			ifElse(hy < my, hx, qx), hy, mx, my, ifElse(hy < my, qx, hx), hy, px, py) {
+			tan := math.Abs(hy-py) / (hx - px)
+			if isLocallyInside(n, h) && (tan < tanMin || (tan == tanMin && (px > float64(m.pt.X) ||
+				(px == float64(m.pt.X) && sectorContainsSector(m, n))))) {
+				m, tanMin = n, tan
+			}
+		}
+		n = n.next
+		if n == stop {
+			break
+		}
+	}
+	return m
+}
+
+func ifElse(cond bool, a, b float64) float64 {
+	if cond {
+		return a
+	}
+	return b
+}
+
+// pointInTriangleF tells whether (px, py) is inside or on the triangle a, b, c.
+func pointInTriangleF(ax, ay, bx, by, cx, cy, px, py float64) bool {
+	return (cx-px)*(ay-py) >= (ax-px)*(cy-py) &&
+		(ax-px)*(by-py) >= (bx-px)*(ay-py) &&
+		(bx-px)*(cy-py) >= (cx-px)*(by-py)
+}
+
+// isLocallyInside tells whether the diagonal from a towards b starts into the
+// polygon at a.
+func isLocallyInside(a, b *earNode) bool {
+	if CrossProduct(a.prev.pt, a.pt, a.next.pt) > 0 {
+		return CrossProduct(a.pt, b.pt, a.next.pt) <= 0 && CrossProduct(a.pt, a.prev.pt, b.pt) <= 0
+	}
+	return CrossProduct(a.pt, b.pt, a.prev.pt) > 0 || CrossProduct(a.pt, a.next.pt, b.pt) > 0
+}
+
+// sectorContainsSector tells whether the sector of n lies within that of m,
+// where both are at the same point.
+func sectorContainsSector(m, n *earNode) bool {
+	return CrossProduct(m.prev.pt, m.pt, n.prev.pt) > 0 && CrossProduct(n.next.pt, m.pt, m.next.pt) > 0
+}
+
+// splitEarRing joins the ring of b to that of a with a bridge a->b, leaving
+// copies of a and b for the way back.
+func splitEarRing(a, b *earNode) *earNode {
+	a2 := &earNode{pt: a.pt, id: a.id}
+	b2 := &earNode{pt: b.pt, id: b.id}
+	an, bp := a.next, b.prev
+
+	a.next, b.prev = b, a
+	a2.next, an.prev = an, a2
+	b2.next, a2.prev = a2, b2
+	bp.next, b2.prev = b2, bp
+	return b2
+}
+
+// clipEars cuts ears off the ring until only a triangle is left. When it
+// goes all the way round without finding one, the ring is first cleaned,
+// then cured of local self-intersections, then split in two along a
+// diagonal, as in earcut.
+func clipEars(ear *earNode, pass int, result *[]Path64) {
+	if ear == nil {
+		return
+	}
+
+	for stop := ear; ear.prev != ear.next; {
+		prev, next := ear.prev, ear.next
+		if isEar(ear) {
+			addEarTriangle(result, prev, ear, next)
+			prev.next, next.prev = next, prev
+			ear, stop = next.next, next.next
+			continue
+		}
+
+		if ear = next; ear != stop {
+			continue
+		}
+		switch pass {
+		case 0:
+			clipEars(filterEarRing(ear), 1, result)
+		case 1:
+			clipEars(cureLocalIntersections(filterEarRing(ear), result), 2, result)
+		case 2:
+			splitEarClip(ear, result)
+		}
+		return
+	}
+}
+
+func addEarTriangle(result *[]Path64, a, b, c *earNode) {
+	if CrossProduct(a.pt, b.pt, c.pt) > 0 {
+		*result = append(*result, Path64{a.pt, b.pt, c.pt})
+	}
+}
+
+// cureLocalIntersections removes the pairs of vertices where the edges
+// either side of them cross, as rounding can leave, cutting off a triangle.
+func cureLocalIntersections(start *earNode, result *[]Path64) *earNode {
+	if start == nil {
+		return nil
+	}
+	p := start
+	for {
+		a, b := p.prev, p.next.next
+		if a.pt != b.pt && earSegsIntersect(a.pt, p.pt, p.next.pt, b.pt) && isLocallyInside(a, b) && isLocallyInside(b, a) {
+			addEarTriangle(result, a, p, b)
+			a.next, b.prev = b, a
+			p, start = b, b
+		}
+		p = p.next
+		if p == start {
+			return filterEarRing(p)
+		}
+	}
+}
+
+// splitEarClip splits the ring in two along a valid diagonal and clips each.
+func splitEarClip(start *earNode, result *[]Path64) {
+	a := start
+	for {
+		for b := a.next.next; b != a.prev; b = b.next {
+			if a.id != b.id && isValidDiagonal(a, b) {
+				c := splitEarRing(a, b)
+				clipEars(filterEarRing(a), 0, result)
+				clipEars(filterEarRing(c), 0, result)
+				return
+			}
+		}
+		if a = a.next; a == start {
+			return
+		}
+	}
+}
+
+// isValidDiagonal tells whether a and b can be joined by a diagonal inside
+// the ring that crosses none of its edges.
+func isValidDiagonal(a, b *earNode) bool {
+	if a.next.id == b.id || a.prev.id == b.id || intersectsEarRing(a, b) {
+		return false
+	}
+	if isLocallyInside(a, b) && isLocallyInside(b, a) && isMiddleInside(a, b) &&
+		(CrossProduct(a.prev.pt, a.pt, b.prev.pt) != 0 || CrossProduct(a.pt, b.prev.pt, b.pt) != 0) {
+		return true
+	}
+	return a.pt == b.pt && CrossProduct(a.prev.pt, a.pt, a.next.pt) < 0 && CrossProduct(b.prev.pt, b.pt, b.next.pt) < 0
+}
+
+func intersectsEarRing(a, b *earNode) bool {
+	for p := a; ; {
+		if p.id != a.id && p.next.id != a.id && p.id != b.id && p.next.id != b.id &&
+			earSegsIntersect(p.pt, p.next.pt, a.pt, b.pt) {
+			return true
+		}
+		if p = p.next; p == a {
+			return false
+		}
+	}
+}
+
+// isMiddleInside tells whether the midpoint of a and b is inside the ring.
+func isMiddleInside(a, b *earNode) bool {
+	inside := false
+	px, py := float64(a.pt.X+b.pt.X)/2, float64(a.pt.Y+b.pt.Y)/2
+	for p := a; ; {
+		y0, y1 := float64(p.pt.Y), float64(p.next.pt.Y)
+		if (y0 > py) != (y1 > py) && px < float64(p.next.pt.X-p.pt.X)*(py-y0)/(y1-y0)+float64(p.pt.X) {
+			inside = !inside
+		}
+		if p = p.next; p == a {
+			return inside
+		}
+	}
+}
+
+// earSegsIntersect tells whether the segments p1-q1 and p2-q2 cross or touch.
+func earSegsIntersect(p1, q1, p2, q2 Point64) bool {
+	sign := func(v float64) int { return cmp.Compare(v, 0) }
+	o1, o2 := sign(CrossProduct(p1, q1, p2)), sign(CrossProduct(p1, q1, q2))
+	o3, o4 := sign(CrossProduct(p2, q2, p1)), sign(CrossProduct(p2, q2, q1))
+	if o1 != o2 && o3 != o4 {
+		return true
+	}
+	return (o1 == 0 && onSegment64(p2, edge64{p1, q1})) || (o2 == 0 && onSegment64(q2, edge64{p1, q1})) ||
+		(o3 == 0 && onSegment64(p1, edge64{p2, q2})) || (o4 == 0 && onSegment64(q1, edge64{p2, q2}))
+}
+
+// filterEarRing removes duplicate and collinear vertices, returning a
+// remaining vertex or nil if too few are left.
+func filterEarRing(ring *earNode) *earNode {
+	n, stop := ring, ring
+	for {
+		if n.next == n || n.prev == n.next {
+			return nil
+		}
+		if n.pt == n.next.pt || CrossProduct(n.prev.pt, n.pt, n.next.pt) == 0 {
+			n.prev.next, n.next.prev = n.next, n.prev
+			n = n.prev
+			stop = n
+			continue
+		}
+		n = n.next
+		if n == stop {
+			return n
+		}
+	}
+}
+
+// isEar tells whether the triangle prev, ear, next is convex and neither
+// contains a reflex vertex of the ring nor is crossed by one of its edges.
+func isEar(ear *earNode) bool {
+	a, b, c := ear.prev, ear, ear.next
+	if CrossProduct(a.pt, b.pt, c.pt) <= 0 {
+		return false
+	}
+
+	ax, ay := float64(a.pt.X), float64(a.pt.Y)
+	bx, by := float64(b.pt.X), float64(b.pt.Y)
+	cx, cy := float64(c.pt.X), float64(c.pt.Y)
+	for p := c.next; p != a; p = p.next {
+		if p.pt == a.pt {
+			continue
+		}
+		if pointInTriangleF(ax, ay, bx, by, cx, cy, float64(p.pt.X), float64(p.pt.Y)) &&
+			CrossProduct(p.prev.pt, p.pt, p.next.pt) <= 0 {
+			return false
+		}
+		// rounding can leave a vertex just across an edge, so the triangle
+		// holding no vertex doesn't mean the ring doesn't cross it
+		if segsIntersect(p.pt, p.next.pt, a.pt, c.pt, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeConvex64 joins neighbouring pieces (positively oriented, sharing an
+// edge in opposite directions) wherever the join is convex at both ends of
+// the shared edge, and returns the pieces left without collinear vertices.
+func mergeConvex64(pieces []Path64) Paths64 {
+	owner := make(map[[2]Point64]int)
+	for i, piece := range pieces {
+		for j := range piece {
+			owner[[2]Point64{piece[j], piece[(j+1)%len(piece)]}] = i
+		}
+	}
+
+	for i := range pieces {
+		for j := 0; j < len(pieces[i]); j++ {
+			a := pieces[i]
+			u, v := a[j], a[(j+1)%len(a)]
+			k, ok := owner[[2]Point64{v, u}]
+			if !ok || k == i || pieces[k] == nil {
+				continue
+			}
+			b := pieces[k]
+			jb := slices.IndexFunc(b, func(pt Point64) bool { return pt == v })
+			if jb < 0 || b[(jb+1)%len(b)] != u {
+				continue
+			}
+
+			na, nb := len(a), len(b)
+			// at u the merged piece turns from a's edge into u onto b's edge out of u
+			if CrossProduct(a[(j+na-1)%na], u, b[(jb+2)%nb]) < 0 ||
+				CrossProduct(b[(jb+nb-1)%nb], v, a[(j+2)%na]) < 0 {
+				continue
+			}
+
+			merged := make(Path64, 0, na+nb-2)
+			for m := 1; m <= na; m++ {
+				merged = append(merged, a[(j+m)%na])
+			}
+			for m := 2; m < nb; m++ {
+				merged = append(merged, b[(jb+m)%nb])
+			}
+
+			delete(owner, [2]Point64{u, v})
+			delete(owner, [2]Point64{v, u})
+			for m := range b {
+				if e := [2]Point64{b[m], b[(m+1)%nb]}; owner[e] == k {
+					owner[e] = i
+				}
+			}
+			pieces[i], pieces[k] = merged, nil
+			j = -1 // a has changed, look at all its edges again
+		}
+	}
+
+	result := make(Paths64, 0)
+	for _, piece := range pieces {
+		if piece != nil {
+			result = append(result, TrimCollinear64(piece, false))
+		}
+	}
+	return result
+}
@@ -0,0 +1,104 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvexDecompose64(t *testing.T) {
+	comb := goclipper2.Path64{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 100}}
+	for x := int64(90); x > 0; x -= 20 {
+		comb = append(comb, goclipper2.Point64{X: x, Y: 100}, goclipper2.Point64{X: x - 5, Y: 30}, goclipper2.Point64{X: x - 10, Y: 100})
+	}
+	comb = append(comb, goclipper2.Point64{X: 0, Y: 100})
+
+	star := goclipper2.Path64{}
+	for i := 0; i < 10; i++ {
+		r := 100.0
+		if i%2 == 1 {
+			r = 40
+		}
+		a := float64(i) * math.Pi / 5
+		star = append(star, goclipper2.NewFloatPoint64(r*math.Cos(a), r*math.Sin(a)))
+	}
+
+	tests := []struct {
+		name      string
+		paths     goclipper2.Paths64
+		maxPieces int
+	}{
+		{name: "convex", paths: goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}, maxPieces: 1},
+		{name: "l shape", paths: goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 50, 50, 50, 50, 100, 0, 100)}, maxPieces: 2},
+		{name: "comb", paths: goclipper2.Paths64{comb}, maxPieces: 11},
+		{name: "star", paths: goclipper2.Paths64{star}, maxPieces: 6},
+		{
+			name: "square hole",
+			paths: goclipper2.Paths64{
+				goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+				goclipper2.MakePath64(40, 40, 40, 60, 60, 60, 60, 40),
+			},
+			maxPieces: 6,
+		},
+		{
+			name: "holes and island",
+			paths: goclipper2.Paths64{
+				goclipper2.Ellipse64(goclipper2.Point64{X: 500, Y: 500}, 400, 300, 0),
+				goclipper2.ReversePath(goclipper2.Ellipse64(goclipper2.Point64{X: 350, Y: 500}, 100, 100, 0)),
+				goclipper2.ReversePath(goclipper2.MakePath64(600, 400, 800, 400, 800, 600, 600, 600)),
+				goclipper2.MakePath64(650, 450, 750, 450, 750, 550, 650, 550),
+			},
+		},
+		{
+			name: "touching hole",
+			paths: goclipper2.Paths64{
+				goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+				goclipper2.MakePath64(0, 50, 50, 25, 50, 75),
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			polytree := goclipper2.BooleanOpPolyTree64(goclipper2.Union, tt.paths, nil, goclipper2.EvenOdd)
+			pieces := goclipper2.ConvexDecompose64(tt.paths, goclipper2.EvenOdd)
+			if tt.maxPieces > 0 {
+				assert.LessOrEqual(t, len(pieces), tt.maxPieces)
+			}
+
+			area := 0.0
+			for _, piece := range pieces {
+				assert.GreaterOrEqual(t, len(piece), 3)
+				for j := range piece {
+					cross := goclipper2.CrossProduct(piece[j], piece[(j+1)%len(piece)], piece[(j+2)%len(piece)])
+					assert.Positive(t, cross, "piece %v is not strictly convex", piece)
+				}
+				area += goclipper2.Area64(piece)
+			}
+
+			// the pieces don't overlap and cover the polytree exactly
+			expect := 0.0
+			for outer := range polytree.Outers() {
+				expect += outer.Area()
+			}
+			assert.Equal(t, expect, area)
+			union := goclipper2.UnionPaths64(pieces, goclipper2.NonZero)
+			assert.Equal(t, 0, len(goclipper2.XorWithClipPaths64(union, tt.paths, goclipper2.EvenOdd)))
+		})
+	}
+}
+
+func TestConvexDecomposeD(t *testing.T) {
+	paths := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 0.5, 0.5, 0.5, 0.5, 1, 0, 1)}
+
+	pieces := goclipper2.ConvexDecomposeD(paths, goclipper2.NonZero)
+	assert.Equal(t, 2, len(pieces))
+	area := 0.0
+	for _, piece := range pieces {
+		area += goclipper2.AreaD(piece)
+	}
+	assert.InDelta(t, 0.75, area, 1e-9)
+}
@@ -0,0 +1,354 @@
+package go_clipper2
+
+import (
+	"math"
+)
+
+// QuadBezierD flattens the quadratic Bezier curve p0-p1-p2 into a polyline.
+// The maximum distance between the curve and the polyline does not exceed
+// tolerance; when tolerance is zero a default relative to the curve size is used.
+func QuadBezierD(p0, p1, p2 PointD, tolerance float64) PathD {
+	result := PathD{p0}
+	return appendQuadBezierD(result, p0, p1, p2, tolerance)
+}
+
+func QuadBezier64(p0, p1, p2 Point64, tolerance float64) Path64 {
+	tmp := QuadBezierD(p0.ToPointD(), p1.ToPointD(), p2.ToPointD(), tolerance)
+	return StripDuplicates(pathDToPath64Rounded(tmp), false)
+}
+
+// CubicBezierD flattens the cubic Bezier curve p0-p1-p2-p3 into a polyline.
+func CubicBezierD(p0, p1, p2, p3 PointD, tolerance float64) PathD {
+	result := PathD{p0}
+	return appendCubicBezierD(result, p0, p1, p2, p3, tolerance)
+}
+
+func CubicBezier64(p0, p1, p2, p3 Point64, tolerance float64) Path64 {
+	tmp := CubicBezierD(p0.ToPointD(), p1.ToPointD(), p2.ToPointD(), p3.ToPointD(), tolerance)
+	return StripDuplicates(pathDToPath64Rounded(tmp), false)
+}
+
+// EllipticalArcD flattens an arc of the ellipse centered at center, rotated by
+// rotation radians, starting at startAngle and sweeping sweepAngle radians
+// (positive sweeps go from +X towards +Y). The tolerance has the same meaning
+// as ClipperOffset.ArcTolerance.
+func EllipticalArcD(center PointD, radiusX, radiusY, rotation, startAngle, sweepAngle, tolerance float64) PathD {
+	if radiusX <= 0 {
+		return PathD{}
+	}
+	if radiusY <= 0 {
+		radiusY = radiusX
+	}
+
+	result := PathD{ellipsePoint(center, radiusX, radiusY, math.Cos(rotation), math.Sin(rotation), startAngle)}
+	return appendEllipticalArcD(result, center, radiusX, radiusY, rotation, startAngle, sweepAngle, tolerance)
+}
+
+func EllipticalArc64(center Point64, radiusX, radiusY, rotation, startAngle, sweepAngle, tolerance float64) Path64 {
+	tmp := EllipticalArcD(center.ToPointD(), radiusX, radiusY, rotation, startAngle, sweepAngle, tolerance)
+	return StripDuplicates(pathDToPath64Rounded(tmp), false)
+}
+
+func appendQuadBezierD(path PathD, p0, p1, p2 PointD, tolerance float64) PathD {
+	ddx := p0.X - 2*p1.X + p2.X
+	ddy := p0.Y - 2*p1.Y + p2.Y
+	tol := bezierTolerance(tolerance, p0, p1, p2)
+
+	// Wang's formula: n >= sqrt(d*(d-1)/8 * max|second difference| / tol)
+	steps := int(math.Ceil(math.Sqrt(0.25 * math.Hypot(ddx, ddy) / tol)))
+	if steps < 1 {
+		steps = 1
+	}
+
+	for i := 1; i < steps; i++ {
+		t := float64(i) / float64(steps)
+		mt := 1 - t
+		a, b, c := mt*mt, 2*mt*t, t*t
+		path = append(path, PointD{
+			X: a*p0.X + b*p1.X + c*p2.X,
+			Y: a*p0.Y + b*p1.Y + c*p2.Y,
+		})
+	}
+
+	return append(path, p2)
+}
+
+func appendCubicBezierD(path PathD, p0, p1, p2, p3 PointD, tolerance float64) PathD {
+	dd1 := math.Hypot(p0.X-2*p1.X+p2.X, p0.Y-2*p1.Y+p2.Y)
+	dd2 := math.Hypot(p1.X-2*p2.X+p3.X, p1.Y-2*p2.Y+p3.Y)
+	tol := bezierTolerance(tolerance, p0, p1, p2, p3)
+
+	steps := int(math.Ceil(math.Sqrt(0.75 * math.Max(dd1, dd2) / tol)))
+	if steps < 1 {
+		steps = 1
+	}
+
+	for i := 1; i < steps; i++ {
+		t := float64(i) / float64(steps)
+		mt := 1 - t
+		a, b, c, d := mt*mt*mt, 3*mt*mt*t, 3*mt*t*t, t*t*t
+		path = append(path, PointD{
+			X: a*p0.X + b*p1.X + c*p2.X + d*p3.X,
+			Y: a*p0.Y + b*p1.Y + c*p2.Y + d*p3.Y,
+		})
+	}
+
+	return append(path, p3)
+}
+
+func appendEllipticalArcD(path PathD, center PointD, radiusX, radiusY, rotation, startAngle, sweepAngle, tolerance float64) PathD {
+	cosR := math.Cos(rotation)
+	sinR := math.Sin(rotation)
+
+	steps := int(math.Ceil(arcStepsPerRad(math.Max(radiusX, radiusY), tolerance) * math.Abs(sweepAngle)))
+	if steps < 1 {
+		steps = 1
+	}
+
+	for i := 1; i <= steps; i++ {
+		angle := startAngle + sweepAngle*float64(i)/float64(steps)
+		path = append(path, ellipsePoint(center, radiusX, radiusY, cosR, sinR, angle))
+	}
+
+	return path
+}
+
+// appendSvgArcD appends an arc given in SVG endpoint parameterization
+// (see SVG 1.1 implementation notes F.6.5) that starts at p0 and ends at p1.
+func appendSvgArcD(path PathD, p0 PointD, radiusX, radiusY, rotation float64, largeArc, sweep bool, p1 PointD, tolerance float64) PathD {
+	radiusX = math.Abs(radiusX)
+	radiusY = math.Abs(radiusY)
+	if radiusX == 0 || radiusY == 0 || p0.Equals(p1) {
+		return append(path, p1)
+	}
+
+	cosR := math.Cos(rotation)
+	sinR := math.Sin(rotation)
+
+	hx := (p0.X - p1.X) / 2
+	hy := (p0.Y - p1.Y) / 2
+	x1 := cosR*hx + sinR*hy
+	y1 := -sinR*hx + cosR*hy
+
+	// scale up radii that are too small to span both end points
+	lambda := sqr(x1)/sqr(radiusX) + sqr(y1)/sqr(radiusY)
+	if lambda > 1 {
+		s := math.Sqrt(lambda)
+		radiusX *= s
+		radiusY *= s
+	}
+
+	rx2, ry2 := sqr(radiusX), sqr(radiusY)
+	num := rx2*ry2 - rx2*sqr(y1) - ry2*sqr(x1)
+	den := rx2*sqr(y1) + ry2*sqr(x1)
+	coef := 0.0
+	if num > 0 && den > 0 {
+		coef = math.Sqrt(num / den)
+	}
+	if largeArc == sweep {
+		coef = -coef
+	}
+
+	cx1 := coef * radiusX * y1 / radiusY
+	cy1 := -coef * radiusY * x1 / radiusX
+	center := PointD{
+		X: cosR*cx1 - sinR*cy1 + (p0.X+p1.X)/2,
+		Y: sinR*cx1 + cosR*cy1 + (p0.Y+p1.Y)/2,
+	}
+
+	startAngle := math.Atan2((y1-cy1)/radiusY, (x1-cx1)/radiusX)
+	endAngle := math.Atan2((-y1-cy1)/radiusY, (-x1-cx1)/radiusX)
+	sweepAngle := endAngle - startAngle
+	if sweep && sweepAngle < 0 {
+		sweepAngle += 2 * math.Pi
+	} else if !sweep && sweepAngle > 0 {
+		sweepAngle -= 2 * math.Pi
+	}
+
+	path = appendEllipticalArcD(path, center, radiusX, radiusY, rotation, startAngle, sweepAngle, tolerance)
+	// snap the last vertex to the exact end point
+	path[len(path)-1] = p1
+	return path
+}
+
+func ellipsePoint(center PointD, radiusX, radiusY, cosR, sinR, angle float64) PointD {
+	x := radiusX * math.Cos(angle)
+	y := radiusY * math.Sin(angle)
+	return PointD{
+		X: center.X + x*cosR - y*sinR,
+		Y: center.Y + x*sinR + y*cosR,
+	}
+}
+
+// arcStepsPerRad mirrors the step calculation ClipperOffset uses for round joins.
+func arcStepsPerRad(radius, tolerance float64) float64 {
+	arcTol := radius * arc
+	if tolerance > Tolerance {
+		arcTol = math.Min(radius, tolerance)
+	}
+	if radius <= Tolerance {
+		return 0
+	}
+	return math.Pi / math.Acos(1-arcTol/radius) / (2 * math.Pi)
+}
+
+func bezierTolerance(tolerance float64, pts ...PointD) float64 {
+	if tolerance > Tolerance {
+		return tolerance
+	}
+
+	minX, minY := math.MaxFloat64, math.MaxFloat64
+	maxX, maxY := -math.MaxFloat64, -math.MaxFloat64
+	for _, pt := range pts {
+		minX, maxX = math.Min(minX, pt.X), math.Max(maxX, pt.X)
+		minY, maxY = math.Min(minY, pt.Y), math.Max(maxY, pt.Y)
+	}
+
+	tol := math.Max(maxX-minX, maxY-minY) * arc
+	if tol <= Tolerance {
+		return 1
+	}
+	return tol
+}
+
+func pathDToPath64Rounded(path PathD) Path64 {
+	result := make(Path64, len(path))
+	for i, pt := range path {
+		result[i] = pt.ToPoint64()
+	}
+	return result
+}
+
+// PathBuilderD accumulates drawing commands and flattens any curves into
+// PathsD that can be passed straight to the clipping and offsetting functions.
+type PathBuilderD struct {
+	// Tolerance is the maximum deviation allowed when flattening curves and arcs.
+	// Zero selects a default relative to each curve's size.
+	Tolerance float64
+
+	paths   PathsD
+	closed  []bool
+	current PathD
+	start   PointD
+}
+
+func NewPathBuilderD(tolerance float64) *PathBuilderD {
+	return &PathBuilderD{
+		Tolerance: tolerance,
+		paths:     make(PathsD, 0),
+		closed:    make([]bool, 0),
+	}
+}
+
+// CurrentPoint returns the end point of the last command.
+func (b *PathBuilderD) CurrentPoint() PointD {
+	if len(b.current) == 0 {
+		return b.start
+	}
+	return b.current[len(b.current)-1]
+}
+
+func (b *PathBuilderD) MoveTo(x, y float64) *PathBuilderD {
+	b.flush(false)
+	b.start = PointD{X: x, Y: y}
+	b.current = PathD{b.start}
+	return b
+}
+
+func (b *PathBuilderD) LineTo(x, y float64) *PathBuilderD {
+	b.ensureStarted()
+	b.current = append(b.current, PointD{X: x, Y: y})
+	return b
+}
+
+func (b *PathBuilderD) QuadTo(cx, cy, x, y float64) *PathBuilderD {
+	b.ensureStarted()
+	b.current = appendQuadBezierD(b.current, b.CurrentPoint(), PointD{X: cx, Y: cy}, PointD{X: x, Y: y}, b.Tolerance)
+	return b
+}
+
+func (b *PathBuilderD) CurveTo(c1x, c1y, c2x, c2y, x, y float64) *PathBuilderD {
+	b.ensureStarted()
+	b.current = appendCubicBezierD(b.current, b.CurrentPoint(),
+		PointD{X: c1x, Y: c1y}, PointD{X: c2x, Y: c2y}, PointD{X: x, Y: y}, b.Tolerance)
+	return b
+}
+
+// ArcTo adds an elliptical arc from the current point to (x, y) using the
+// SVG endpoint parameterization. rotation is in radians.
+func (b *PathBuilderD) ArcTo(radiusX, radiusY, rotation float64, largeArc, sweep bool, x, y float64) *PathBuilderD {
+	b.ensureStarted()
+	b.current = appendSvgArcD(b.current, b.CurrentPoint(), radiusX, radiusY, rotation, largeArc, sweep, PointD{X: x, Y: y}, b.Tolerance)
+	return b
+}
+
+// Close closes the current sub-path; the next command starts at its first point.
+func (b *PathBuilderD) Close() *PathBuilderD {
+	b.flush(true)
+	return b
+}
+
+// Paths returns every sub-path built so far, open or closed.
+func (b *PathBuilderD) Paths() PathsD {
+	b.flush(false)
+	return b.paths
+}
+
+// ClosedPaths returns only the sub-paths that were terminated with Close.
+func (b *PathBuilderD) ClosedPaths() PathsD {
+	return b.filter(true)
+}
+
+// OpenPaths returns only the sub-paths that were not terminated with Close.
+func (b *PathBuilderD) OpenPaths() PathsD {
+	return b.filter(false)
+}
+
+func (b *PathBuilderD) filter(closed bool) PathsD {
+	b.flush(false)
+	result := make(PathsD, 0, len(b.paths))
+	for i, path := range b.paths {
+		if b.closed[i] == closed {
+			result = append(result, path)
+		}
+	}
+	return result
+}
+
+func (b *PathBuilderD) ensureStarted() {
+	if len(b.current) == 0 {
+		b.current = PathD{b.start}
+	}
+}
+
+func (b *PathBuilderD) flush(closed bool) {
+	path := stripDuplicatesD(b.current, closed)
+	b.current = nil
+	if len(path) < 2 {
+		return
+	}
+	b.paths = append(b.paths, path)
+	b.closed = append(b.closed, closed)
+}
+
+func stripDuplicatesD(path PathD, isClosedPath bool) PathD {
+	if len(path) == 0 {
+		return path
+	}
+
+	result := make(PathD, 0, len(path))
+	lastPt := path[0]
+	result = append(result, lastPt)
+	for _, pt := range path[1:] {
+		if lastPt.NEquals(pt) {
+			lastPt = pt
+			result = append(result, lastPt)
+		}
+	}
+
+	if isClosedPath && len(result) > 1 && lastPt.Equals(result[0]) {
+		result = result[:len(result)-1]
+	}
+
+	return result
+}
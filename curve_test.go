@@ -0,0 +1,121 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBezierFlattening(t *testing.T) {
+	var (
+		p0 = goclipper2.PointD{X: 0, Y: 0}
+		p1 = goclipper2.PointD{X: 50, Y: 100}
+		p2 = goclipper2.PointD{X: 100, Y: 100}
+		p3 = goclipper2.PointD{X: 150, Y: 0}
+	)
+
+	tests := []struct {
+		name      string
+		tolerance float64
+		flatten   func(tol float64) goclipper2.PathD
+		curve     func(t float64) goclipper2.PointD
+	}{
+		{
+			name:      "quadratic",
+			tolerance: 0.5,
+			flatten: func(tol float64) goclipper2.PathD {
+				return goclipper2.QuadBezierD(p0, p1, p3, tol)
+			},
+			curve: func(t float64) goclipper2.PointD {
+				mt := 1 - t
+				return goclipper2.PointD{
+					X: mt*mt*p0.X + 2*mt*t*p1.X + t*t*p3.X,
+					Y: mt*mt*p0.Y + 2*mt*t*p1.Y + t*t*p3.Y,
+				}
+			},
+		},
+		{
+			name:      "cubic",
+			tolerance: 0.1,
+			flatten: func(tol float64) goclipper2.PathD {
+				return goclipper2.CubicBezierD(p0, p1, p2, p3, tol)
+			},
+			curve: func(t float64) goclipper2.PointD {
+				mt := 1 - t
+				return goclipper2.PointD{
+					X: mt*mt*mt*p0.X + 3*mt*mt*t*p1.X + 3*mt*t*t*p2.X + t*t*t*p3.X,
+					Y: mt*mt*mt*p0.Y + 3*mt*mt*t*p1.Y + 3*mt*t*t*p2.Y + t*t*t*p3.Y,
+				}
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			path := tt.flatten(tt.tolerance)
+			assert.Equal(t, p0, path[0])
+			assert.Equal(t, p3, path[len(path)-1])
+
+			for s := 0; s <= 200; s++ {
+				pt := tt.curve(float64(s) / 200)
+				assert.LessOrEqual(t, distToPolyline(pt, path), tt.tolerance+1e-9)
+			}
+
+			coarse := tt.flatten(tt.tolerance * 10)
+			assert.Less(t, len(coarse), len(path))
+		})
+	}
+}
+
+func TestEllipticalArcD(t *testing.T) {
+	center := goclipper2.PointD{X: 10, Y: 20}
+	arc := goclipper2.EllipticalArcD(center, 100, 50, 0, 0, math.Pi/2, 0.25)
+
+	assert.InDelta(t, 110, arc[0].X, 1e-9)
+	assert.InDelta(t, 20, arc[0].Y, 1e-9)
+	assert.InDelta(t, 10, arc[len(arc)-1].X, 1e-9)
+	assert.InDelta(t, 70, arc[len(arc)-1].Y, 1e-9)
+
+	// a full circle flattened with the offset tolerance should match a round offset closely
+	circle := goclipper2.EllipticalArcD(goclipper2.PointD{}, 100, 100, 0, 0, 2*math.Pi, 0.25)
+	area := math.Abs(goclipper2.AreaD(circle[:len(circle)-1]))
+	assert.InDelta(t, math.Pi*100*100, area, math.Pi*100*100*0.01)
+}
+
+func TestPathBuilderD(t *testing.T) {
+	b := goclipper2.NewPathBuilderD(0.1)
+	b.MoveTo(0, 0).LineTo(100, 0).LineTo(100, 100).LineTo(0, 100).Close()
+	b.MoveTo(50, 20).ArcTo(30, 30, 0, true, true, 50, 80).ArcTo(30, 30, 0, true, true, 50, 20).Close()
+	b.MoveTo(200, 0).CurveTo(250, 100, 300, 100, 350, 0)
+
+	assert.Equal(t, 3, len(b.Paths()))
+	assert.Equal(t, 2, len(b.ClosedPaths()))
+	assert.Equal(t, 1, len(b.OpenPaths()))
+
+	square := b.ClosedPaths()[0]
+	assert.Equal(t, goclipper2.MakePathD(0, 0, 100, 0, 100, 100, 0, 100), square)
+
+	hole := b.ClosedPaths()[1]
+	assert.InDelta(t, math.Pi*30*30, math.Abs(goclipper2.AreaD(hole)), 2*math.Pi*30*0.1)
+
+	result := goclipper2.UnionPathsD(b.ClosedPaths(), goclipper2.EvenOdd)
+	assert.Equal(t, 2, len(result))
+	assert.InDelta(t, 100*100-math.Pi*30*30, goclipper2.AreaPathsD(result), 2*math.Pi*30*0.1)
+}
+
+func distToPolyline(pt goclipper2.PointD, path goclipper2.PathD) float64 {
+	best := math.MaxFloat64
+	for i := 1; i < len(path); i++ {
+		a, b := path[i-1], path[i]
+		dx, dy := b.X-a.X, b.Y-a.Y
+		q := 0.0
+		if l := dx*dx + dy*dy; l > 0 {
+			q = math.Max(0, math.Min(1, ((pt.X-a.X)*dx+(pt.Y-a.Y)*dy)/l))
+		}
+		best = math.Min(best, math.Hypot(pt.X-a.X-q*dx, pt.Y-a.Y-q*dy))
+	}
+	return best
+}
@@ -0,0 +1,226 @@
+package go_clipper2
+
+import (
+	"math"
+)
+
+// ClosestPoint64 returns the point on the outline of paths nearest to pt,
+// rounded to integer coordinates, and its distance from pt. With isClosedPath
+// each path gets a segment from its last vertex back to its first; a point
+// inside a polygon is measured to its edges. The distance is +Inf if paths
+// has no points.
+func ClosestPoint64(pt Point64, paths Paths64, isClosedPath bool) (Point64, float64) {
+	return NewDistanceIndex64(paths, isClosedPath).ClosestPoint(pt)
+}
+
+// ClosestPointD is ClosestPoint64 for PathsD, with coordinates rounded to
+// precisionV decimal places (2 by default).
+func ClosestPointD(pt PointD, paths PathsD, isClosedPath bool, precisionV ...int) (PointD, float64) {
+	return NewDistanceIndexD(paths, isClosedPath, precisionV...).ClosestPoint(pt)
+}
+
+// DistancePaths64 returns the shortest distance between paths1 and paths2.
+// Closed paths are taken as the polygons they bound under the NonZero fill
+// rule, so the distance is 0 if they overlap or one contains the other; open
+// paths are only 0 apart where they touch or cross. It's +Inf if either has
+// no points.
+func DistancePaths64(paths1, paths2 Paths64, isClosedPath bool) float64 {
+	return NewDistanceIndex64(paths1, isClosedPath).Distance(paths2)
+}
+
+func DistancePathsD(paths1, paths2 PathsD, isClosedPath bool, precisionV ...int) float64 {
+	return NewDistanceIndexD(paths1, isClosedPath, precisionV...).Distance(paths2)
+}
+
+// HausdorffDistance64 returns how far the outlines of paths1 and paths2 stray
+// from each other: the greatest distance from any point of either outline,
+// along its edges as well as at its vertices, to the nearest point of the
+// other's, to within half a unit. It's +Inf if only one of them has points.
+func HausdorffDistance64(paths1, paths2 Paths64, isClosedPath bool) float64 {
+	return max(
+		NewDistanceIndex64(paths2, isClosedPath).directedHausdorff(paths1, isClosedPath),
+		NewDistanceIndex64(paths1, isClosedPath).directedHausdorff(paths2, isClosedPath),
+	)
+}
+
+func HausdorffDistanceD(paths1, paths2 PathsD, isClosedPath bool, precisionV ...int) float64 {
+	scale := distanceScale(precisionV...)
+	return HausdorffDistance64(ScalePathsDToPaths64(paths1, scale), ScalePathsDToPaths64(paths2, scale), isClosedPath) / scale
+}
+
+// DistanceIndex64 answers distance queries against the same paths many times
+// over. Their segments are kept in a PathsIndex64, so each query only visits
+// those near it. The package level functions build one per call.
+type DistanceIndex64 struct {
+	paths        Paths64
+	isClosedPath bool
+	segments     *PathsIndex64
+}
+
+func NewDistanceIndex64(paths Paths64, isClosedPath bool) *DistanceIndex64 {
+	return &DistanceIndex64{
+		paths:        paths,
+		isClosedPath: isClosedPath,
+		segments:     NewPathsIndex64(pathSegments64(paths, isClosedPath)),
+	}
+}
+
+// ClosestPoint is ClosestPoint64 for the indexed paths.
+func (idx *DistanceIndex64) ClosestPoint(pt Point64) (Point64, float64) {
+	i, dist := idx.segments.Nearest(pt)
+	if i < 0 {
+		return Point64{}, math.Inf(1)
+	}
+	seg := idx.segments.Path(i)
+	return getClosestPtOnSegment(pt, seg[0], seg[1]), dist
+}
+
+// Distance is DistancePaths64 from the indexed paths to paths, which are
+// taken to be closed or open as the indexed ones are.
+func (idx *DistanceIndex64) Distance(paths Paths64) float64 {
+	if idx.isClosedPath && (anyVertexInside64(paths, idx.paths) || anyVertexInside64(idx.paths, paths)) {
+		return 0
+	}
+
+	best := math.Inf(1)
+	for _, seg := range pathSegments64(paths, idx.isClosedPath) {
+		// a nearer segment must come within best of this one's bounds
+		if i, dist := idx.segments.Nearest(seg[0]); i >= 0 && dist < best {
+			best = dist
+		}
+		if math.IsInf(best, 1) {
+			return best
+		}
+		margin := int64(math.Ceil(best))
+		rect := GetBounds64(seg)
+		rect.left, rect.top, rect.right, rect.bottom = rect.left-margin, rect.top-margin, rect.right+margin, rect.bottom+margin
+		for _, i := range idx.segments.Query(rect) {
+			other := idx.segments.Path(i)
+			best = math.Min(best, segmentsDistance64(seg[0], seg[1], other[0], other[1]))
+		}
+		if best == 0 {
+			break
+		}
+	}
+	return best
+}
+
+// directedHausdorff returns the greatest distance from the outline of paths
+// to the indexed outline, 0 if paths has no points. Each segment is halved
+// until no part of it can be further than the greatest distance so far by
+// more than half a unit, as the distance along a segment changes no faster
+// than the segment runs.
+func (idx *DistanceIndex64) directedHausdorff(paths Paths64, isClosedPath bool) float64 {
+	result := 0.0
+	var bisect func(p, q PointD, dp, dq float64)
+	bisect = func(p, q PointD, dp, dq float64) {
+		if (dp+dq+math.Hypot(q.X-p.X, q.Y-p.Y))/2 <= result+0.5 {
+			return
+		}
+		m := PointD{X: (p.X + q.X) / 2, Y: (p.Y + q.Y) / 2}
+		dm := idx.distanceAt(m)
+		result = math.Max(result, dm)
+		bisect(p, m, dp, dm)
+		bisect(m, q, dm, dq)
+	}
+
+	for _, seg := range pathSegments64(paths, isClosedPath) {
+		_, dp := idx.ClosestPoint(seg[0])
+		_, dq := idx.ClosestPoint(seg[1])
+		result = math.Max(result, math.Max(dp, dq))
+		bisect(seg[0].ToPointD(), seg[1].ToPointD(), dp, dq)
+	}
+	return result
+}
+
+// distanceAt returns the distance from pt, which needn't be on the integer
+// grid, to the indexed outline. The segment nearest pt rounded is within a
+// unit of the one nearest pt.
+func (idx *DistanceIndex64) distanceAt(pt PointD) float64 {
+	rounded := NewFloatPoint64(pt.X, pt.Y)
+	i, dist := idx.segments.Nearest(rounded)
+	if i < 0 {
+		return math.Inf(1)
+	}
+	margin := int64(math.Ceil(dist)) + 1
+	best := math.Inf(1)
+	for _, i := range idx.segments.Query(NewRect64(rounded.X-margin, rounded.Y-margin, rounded.X+margin, rounded.Y+margin)) {
+		seg := idx.segments.Path(i)
+		best = math.Min(best, segmentDistanceSqrD(pt.X, pt.Y, seg[0].ToPointD(), seg[1].ToPointD()))
+	}
+	return math.Sqrt(best)
+}
+
+// DistanceIndexD is DistanceIndex64 for PathsD, with coordinates rounded to
+// precisionV decimal places (2 by default).
+type DistanceIndexD struct {
+	index *DistanceIndex64
+	scale float64
+}
+
+func NewDistanceIndexD(paths PathsD, isClosedPath bool, precisionV ...int) *DistanceIndexD {
+	scale := distanceScale(precisionV...)
+	return &DistanceIndexD{
+		index: NewDistanceIndex64(ScalePathsDToPaths64(paths, scale), isClosedPath),
+		scale: scale,
+	}
+}
+
+func (idx *DistanceIndexD) ClosestPoint(pt PointD) (PointD, float64) {
+	closest, dist := idx.index.ClosestPoint(NewFloatPoint64(pt.X*idx.scale, pt.Y*idx.scale))
+	return PointD{X: float64(closest.X) / idx.scale, Y: float64(closest.Y) / idx.scale}, dist / idx.scale
+}
+
+func (idx *DistanceIndexD) Distance(paths PathsD) float64 {
+	return idx.index.Distance(ScalePathsDToPaths64(paths, idx.scale)) / idx.scale
+}
+
+func distanceScale(precisionV ...int) float64 {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+	checkPrecision(precision)
+	return math.Pow(10, float64(precision))
+}
+
+// pathSegments64 returns the segments of paths as two point paths. A path
+// with a single point becomes a segment of no length.
+func pathSegments64(paths Paths64, isClosedPath bool) Paths64 {
+	result := make(Paths64, 0)
+	for _, path := range paths {
+		switch {
+		case len(path) == 1:
+			result = append(result, Path64{path[0], path[0]})
+		case len(path) > 1:
+			for i := 1; i < len(path); i++ {
+				result = append(result, Path64{path[i-1], path[i]})
+			}
+			if isClosedPath && len(path) > 2 {
+				result = append(result, Path64{path[len(path)-1], path[0]})
+			}
+		}
+	}
+	return result
+}
+
+// anyVertexInside64 reports whether the first vertex of any path of paths1
+// is inside or on paths2.
+func anyVertexInside64(paths1, paths2 Paths64) bool {
+	for _, path := range paths1 {
+		if len(path) > 0 && PointInPaths64(path[0], paths2, NonZero) != IsOutside {
+			return true
+		}
+	}
+	return false
+}
+
+func segmentsDistance64(a1, a2, b1, b2 Point64) float64 {
+	if segsIntersect(a1, a2, b1, b2, true) {
+		return 0
+	}
+	return math.Sqrt(min(
+		segmentDistanceSqr64(a1, b1, b2), segmentDistanceSqr64(a2, b1, b2),
+		segmentDistanceSqr64(b1, a1, a2), segmentDistanceSqr64(b2, a1, a2),
+	))
+}
@@ -0,0 +1,145 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosestPoint64(t *testing.T) {
+	square := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}
+
+	tests := []struct {
+		name         string
+		pt           goclipper2.Point64
+		isClosedPath bool
+		closest      goclipper2.Point64
+		dist         float64
+	}{
+		{name: "outside an edge", pt: goclipper2.Point64{X: 50, Y: -30}, isClosedPath: true, closest: goclipper2.Point64{X: 50, Y: 0}, dist: 30},
+		{name: "outside a corner", pt: goclipper2.Point64{X: 130, Y: 140}, isClosedPath: true, closest: goclipper2.Point64{X: 100, Y: 100}, dist: 50},
+		{name: "inside", pt: goclipper2.Point64{X: 20, Y: 60}, isClosedPath: true, closest: goclipper2.Point64{X: 0, Y: 60}, dist: 20},
+		{name: "on an edge", pt: goclipper2.Point64{X: 100, Y: 30}, isClosedPath: true, closest: goclipper2.Point64{X: 100, Y: 30}, dist: 0},
+		{name: "open", pt: goclipper2.Point64{X: -10, Y: 60}, isClosedPath: false, closest: goclipper2.Point64{X: 0, Y: 100}, dist: math.Sqrt(10*10 + 40*40)},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			closest, dist := goclipper2.ClosestPoint64(tt.pt, square, tt.isClosedPath)
+			assert.Equal(t, tt.closest, closest)
+			assert.InDelta(t, tt.dist, dist, 1e-9)
+		})
+	}
+
+	_, dist := goclipper2.ClosestPoint64(goclipper2.Point64{}, nil, true)
+	assert.True(t, math.IsInf(dist, 1))
+
+	closest, dist := goclipper2.ClosestPointD(goclipper2.PointD{X: 0.5, Y: -0.3}, goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 1)}, true)
+	assert.InDelta(t, 0.5, closest.X, 1e-9)
+	assert.InDelta(t, 0, closest.Y, 1e-9)
+	assert.InDelta(t, 0.3, dist, 1e-9)
+}
+
+func TestDistancePaths64(t *testing.T) {
+	square := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}
+	frame := goclipper2.Paths64{
+		goclipper2.MakePath64(-100, -100, 200, -100, 200, 200, -100, 200),
+		goclipper2.MakePath64(-50, -50, -50, 150, 150, 150, 150, -50),
+	}
+
+	tests := []struct {
+		name         string
+		paths        goclipper2.Paths64
+		isClosedPath bool
+		dist         float64
+	}{
+		{name: "apart", paths: goclipper2.Paths64{goclipper2.MakePath64(130, 140, 200, 140, 200, 200, 130, 200)}, isClosedPath: true, dist: 50},
+		{name: "edge to edge", paths: goclipper2.Paths64{goclipper2.MakePath64(120, 50, 140, -10, 140, 110)}, isClosedPath: true, dist: 20},
+		{name: "overlapping", paths: goclipper2.Paths64{goclipper2.MakePath64(50, 50, 150, 50, 150, 150, 50, 150)}, isClosedPath: true, dist: 0},
+		{name: "contained", paths: goclipper2.Paths64{goclipper2.MakePath64(40, 40, 60, 40, 60, 60, 40, 60)}, isClosedPath: true, dist: 0},
+		{name: "containing", paths: goclipper2.Paths64{goclipper2.MakePath64(-40, -40, 140, -40, 140, 140, -40, 140)}, isClosedPath: true, dist: 0},
+		{name: "in a hole", paths: frame, isClosedPath: true, dist: 50},
+		{name: "open inside", paths: goclipper2.Paths64{goclipper2.MakePath64(40, 40, 60, 40, 60, 90)}, isClosedPath: false, dist: 10},
+		{name: "open crossing", paths: goclipper2.Paths64{goclipper2.MakePath64(50, 50, 50, 150)}, isClosedPath: false, dist: 0},
+		{name: "empty", paths: nil, isClosedPath: true, dist: math.Inf(1)},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			assert.InDelta(t, tt.dist, goclipper2.DistancePaths64(square, tt.paths, tt.isClosedPath), 1e-9)
+			assert.InDelta(t, tt.dist, goclipper2.DistancePaths64(tt.paths, square, tt.isClosedPath), 1e-9)
+		})
+	}
+
+	squareD := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 0, 1)}
+	assert.InDelta(t, 0.5, goclipper2.DistancePathsD(squareD, goclipper2.PathsD{goclipper2.MakePathD(1.5, 0, 2, 0, 2, 1)}, true), 1e-9)
+}
+
+func TestDistanceIndex64(t *testing.T) {
+	// a ring of many small squares, queried from random points
+	paths := goclipper2.Paths64{}
+	for i := 0; i < 400; i++ {
+		a := float64(i) * 2 * math.Pi / 400
+		x, y := int64(1000*math.Cos(a)), int64(1000*math.Sin(a))
+		paths = append(paths, goclipper2.MakePath64(x, y, x+5, y, x+5, y+5, x, y+5))
+	}
+	idx := goclipper2.NewDistanceIndex64(paths, true)
+
+	for i := int64(0); i < 200; i++ {
+		pt := goclipper2.Point64{X: (i*7919)%3000 - 1500, Y: (i*104729)%3000 - 1500}
+		expect := math.Inf(1)
+		for _, path := range paths {
+			for j := range path {
+				expect = math.Min(expect, pointSegmentDistance(pt, path[j], path[(j+1)%len(path)]))
+			}
+		}
+		_, dist := idx.ClosestPoint(pt)
+		assert.InDelta(t, expect, dist, 1e-9)
+	}
+
+	probe := goclipper2.Paths64{goclipper2.MakePath64(-50, -50, 50, -50, 50, 50, -50, 50)}
+	assert.InDelta(t, goclipper2.DistancePaths64(paths, probe, true), idx.Distance(probe), 1e-9)
+	assert.Greater(t, idx.Distance(probe), 900.0)
+}
+
+func TestHausdorffDistance64(t *testing.T) {
+	circle := goclipper2.Paths64{goclipper2.Ellipse64(goclipper2.Point64{}, 1000, 1000, 200)}
+	simplified := goclipper2.SimplifyPaths64(circle, 10, true)
+	dist := goclipper2.HausdorffDistance64(circle, simplified, true)
+	assert.Greater(t, dist, 0.0)
+	assert.LessOrEqual(t, dist, 10.0)
+
+	square := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}
+	shifted := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 130, 0, 100)}
+	assert.InDelta(t, 30, goclipper2.HausdorffDistance64(square, shifted, true), 1e-9)
+	assert.Equal(t, 0.0, goclipper2.HausdorffDistance64(square, square, true))
+	assert.True(t, math.IsInf(goclipper2.HausdorffDistance64(square, nil, true), 1))
+
+	// only the closing segment differs
+	path := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100)}
+	assert.InDelta(t, 0, goclipper2.HausdorffDistance64(path, goclipper2.Paths64{goclipper2.ReversePath(path[0])}, false), 1e-9)
+
+	// every vertex lies on the other outline, but the middle of the long
+	// segment is 40 from the short ones
+	long := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0)}
+	short := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 10, 0), goclipper2.MakePath64(90, 0, 100, 0)}
+	assert.InDelta(t, 40, goclipper2.HausdorffDistance64(long, short, false), 1)
+	assert.InDelta(t, 40, goclipper2.HausdorffDistance64(short, long, false), 1)
+
+	squareD := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 0, 1)}
+	shiftedD := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 1.25, 0, 1)}
+	assert.InDelta(t, 0.25, goclipper2.HausdorffDistanceD(squareD, shiftedD, true), 1e-9)
+	// the corners of a diamond are on the square, its edges cut inside it
+	diamondD := goclipper2.PathsD{goclipper2.MakePathD(0.5, 0, 1, 0.5, 0.5, 1, 0, 0.5)}
+	assert.InDelta(t, math.Sqrt(2)/4, goclipper2.HausdorffDistanceD(squareD, diamondD, true), 0.01)
+}
+
+func pointSegmentDistance(pt, a, b goclipper2.Point64) float64 {
+	dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+	px, py := float64(pt.X-a.X), float64(pt.Y-a.Y)
+	t := math.Max(0, math.Min(1, (px*dx+py*dy)/(dx*dx+dy*dy)))
+	return math.Hypot(px-t*dx, py-t*dy)
+}
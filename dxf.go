@@ -0,0 +1,486 @@
+package go_clipper2
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type dxfPair struct {
+	code  int
+	value string
+}
+
+type dxfVertex struct {
+	pt    PointD
+	bulge float64
+}
+
+// ReadDxfD reads the ENTITIES section of an ASCII DXF file. LWPOLYLINE,
+// POLYLINE, LINE, ARC and CIRCLE entities are supported, bulges and arcs are
+// flattened with the given tolerance (see ClipperOffset.ArcTolerance).
+// Closed polylines and circles are returned in closed, everything else in open.
+// Entities drawn in a plane seen from below, with an extrusion direction of
+// -Z as mirrored geometry often has, are mirrored back into world coordinates;
+// other planes aren't supported.
+func ReadDxfD(r io.Reader, tolerance float64) (closed, open PathsD, err error) {
+	pairs, err := readDxfPairs(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	closed, open = PathsD{}, PathsD{}
+	inEntities := false
+	for i := 0; i < len(pairs); {
+		p := pairs[i]
+		if p.code != 0 {
+			i++
+			continue
+		}
+
+		switch p.value {
+		case "SECTION":
+			inEntities = i+1 < len(pairs) && pairs[i+1].code == 2 && pairs[i+1].value == "ENTITIES"
+			i++
+			continue
+		case "ENDSEC":
+			inEntities = false
+			i++
+			continue
+		case "EOF":
+			return closed, open, nil
+		}
+
+		if !inEntities {
+			i++
+			continue
+		}
+
+		entity, next := dxfEntity(pairs, i)
+		var (
+			path     PathD
+			isClosed bool
+		)
+		switch p.value {
+		case "LWPOLYLINE":
+			path, isClosed, err = dxfLwPolyline(entity, tolerance)
+		case "POLYLINE":
+			path, isClosed, next, err = dxfPolyline(pairs, entity, next, tolerance)
+		case "LINE":
+			path, err = dxfLine(entity)
+		case "ARC":
+			path, err = dxfArc(entity, tolerance, false)
+		case "CIRCLE":
+			path, err = dxfArc(entity, tolerance, true)
+			isClosed = true
+		}
+		if err == nil && p.value != "LINE" {
+			// a LINE's points are in world coordinates already
+			path, err = dxfObjectToWorld(entity, path)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(path) > 1 {
+			if isClosed {
+				closed = append(closed, path)
+			} else {
+				open = append(open, path)
+			}
+		}
+		i = next
+	}
+
+	return closed, open, nil
+}
+
+// WriteDxfPathsD writes paths as closed POLYLINE entities on the given layer,
+// in an R12 (AC1009) file, which CAD and CAM programs all read.
+func WriteDxfPathsD(w io.Writer, layer string, paths PathsD) error {
+	dw := newDxfWriter(w)
+	dw.header([]string{layer})
+	for _, path := range paths {
+		dw.polyline(layer, path)
+	}
+	return dw.finish()
+}
+
+// WriteDxfPolyTreeD writes every polygon of the tree as a closed POLYLINE, as
+// WriteDxfPathsD does, putting outer polygons on outerLayer and holes on
+// holeLayer.
+func WriteDxfPolyTreeD(w io.Writer, polytree *PolyTreeD, outerLayer, holeLayer string) error {
+	dw := newDxfWriter(w)
+	dw.header([]string{outerLayer, holeLayer})
+
+	for pp := range polytree.All() {
+		layer := outerLayer
+		if pp.IsHole() {
+			layer = holeLayer
+		}
+		dw.polyline(layer, (&PolyPathD{pp}).PolygonD())
+	}
+
+	return dw.finish()
+}
+
+// readDxfPairs reads the group code and value pairs of r, up to and
+// including the 0/EOF pair.
+func readDxfPairs(r io.Reader) ([]dxfPair, error) {
+	scanner := bufio.NewScanner(r)
+	pairs := make([]dxfPair, 0)
+	line := 0
+	for scanner.Scan() {
+		line++
+		codeStr := strings.TrimSpace(scanner.Text())
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("%w: missing value for group code at line %d", ErrInvalidDxf, line)
+		}
+		line++
+
+		code, err := strconv.Atoi(codeStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad group code %q at line %d", ErrInvalidDxf, codeStr, line-1)
+		}
+		pair := dxfPair{code: code, value: strings.TrimSpace(scanner.Text())}
+		pairs = append(pairs, pair)
+		if pair.code == 0 && pair.value == "EOF" {
+			// anything after the end, a trailing blank line say, isn't DXF
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// dxfEntity returns the group pairs of the entity starting at i (excluding the
+// leading 0 pair) and the index of the next entity.
+func dxfEntity(pairs []dxfPair, i int) ([]dxfPair, int) {
+	j := i + 1
+	for j < len(pairs) && pairs[j].code != 0 {
+		j++
+	}
+	return pairs[i+1 : j], j
+}
+
+func dxfFloat(p dxfPair) (float64, error) {
+	v, err := strconv.ParseFloat(p.value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad value %q for group code %d", ErrInvalidDxf, p.value, p.code)
+	}
+	return v, nil
+}
+
+func dxfInt(p dxfPair) (int, error) {
+	v, err := strconv.Atoi(p.value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad value %q for group code %d", ErrInvalidDxf, p.value, p.code)
+	}
+	return v, nil
+}
+
+func dxfLwPolyline(entity []dxfPair, tolerance float64) (PathD, bool, error) {
+	var (
+		verts []dxfVertex
+		flags int
+		err   error
+	)
+	for _, p := range entity {
+		switch p.code {
+		case 10:
+			var x float64
+			if x, err = dxfFloat(p); err != nil {
+				return nil, false, err
+			}
+			verts = append(verts, dxfVertex{pt: PointD{X: x}})
+		case 20, 42:
+			if len(verts) == 0 {
+				continue
+			}
+			var v float64
+			if v, err = dxfFloat(p); err != nil {
+				return nil, false, err
+			}
+			if p.code == 20 {
+				verts[len(verts)-1].pt.Y = v
+			} else {
+				verts[len(verts)-1].bulge = v
+			}
+		case 70:
+			if flags, err = dxfInt(p); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	isClosed := flags&1 != 0
+	return dxfBulgePath(verts, isClosed, tolerance), isClosed, nil
+}
+
+func dxfPolyline(pairs, entity []dxfPair, next int, tolerance float64) (PathD, bool, int, error) {
+	flags := 0
+	for _, p := range entity {
+		if p.code == 70 {
+			var err error
+			if flags, err = dxfInt(p); err != nil {
+				return nil, false, next, err
+			}
+		}
+	}
+
+	var verts []dxfVertex
+	for next < len(pairs) && pairs[next].value == "VERTEX" {
+		var vertex []dxfPair
+		vertex, next = dxfEntity(pairs, next)
+
+		var v dxfVertex
+		for _, p := range vertex {
+			if p.code != 10 && p.code != 20 && p.code != 42 {
+				continue
+			}
+			f, err := dxfFloat(p)
+			if err != nil {
+				return nil, false, next, err
+			}
+			switch p.code {
+			case 10:
+				v.pt.X = f
+			case 20:
+				v.pt.Y = f
+			case 42:
+				v.bulge = f
+			}
+		}
+		verts = append(verts, v)
+	}
+	if next < len(pairs) && pairs[next].value == "SEQEND" {
+		_, next = dxfEntity(pairs, next)
+	}
+
+	isClosed := flags&1 != 0
+	return dxfBulgePath(verts, isClosed, tolerance), isClosed, next, nil
+}
+
+func dxfBulgePath(verts []dxfVertex, isClosed bool, tolerance float64) PathD {
+	if len(verts) == 0 {
+		return PathD{}
+	}
+
+	result := PathD{verts[0].pt}
+	cnt := len(verts)
+	last := cnt - 1
+	if isClosed {
+		last = cnt
+	}
+	for i := 0; i < last; i++ {
+		p0 := verts[i].pt
+		p1 := verts[(i+1)%cnt].pt
+		result = appendBulgeD(result, p0, p1, verts[i].bulge, tolerance)
+	}
+
+	return stripDuplicatesD(result, isClosed)
+}
+
+// appendBulgeD appends the arc from p0 to p1 described by a DXF bulge, the
+// tangent of a quarter of the included angle (positive is counter-clockwise).
+func appendBulgeD(path PathD, p0, p1 PointD, bulge, tolerance float64) PathD {
+	if isAlmostZero(bulge) {
+		return append(path, p1)
+	}
+
+	chord := math.Hypot(p1.X-p0.X, p1.Y-p0.Y)
+	angle := 4 * math.Atan(bulge)
+	radius := chord / (2 * math.Abs(math.Sin(angle/2)))
+	return appendSvgArcD(path, p0, radius, radius, 0, math.Abs(angle) > math.Pi, bulge > 0, p1, tolerance)
+}
+
+// dxfObjectToWorld maps path from the object coordinates of a planar entity
+// to world coordinates. By the arbitrary axis algorithm, an extrusion
+// direction (210, 220, 230) of -Z gives an X axis of -X, so the entity is
+// mirrored in X; +Z leaves it as it is.
+func dxfObjectToWorld(entity []dxfPair, path PathD) (PathD, error) {
+	var normal [3]float64
+	normal[2] = 1
+	for _, p := range entity {
+		if p.code != 210 && p.code != 220 && p.code != 230 {
+			continue
+		}
+		v, err := dxfFloat(p)
+		if err != nil {
+			return nil, err
+		}
+		normal[(p.code-210)/10] = v
+	}
+
+	length := math.Sqrt(normal[0]*normal[0] + normal[1]*normal[1] + normal[2]*normal[2])
+	if length == 0 || math.Abs(normal[0]) > 1e-9*length || math.Abs(normal[1]) > 1e-9*length {
+		return nil, fmt.Errorf("%w: unsupported extrusion direction %v", ErrInvalidDxf, normal)
+	}
+	if normal[2] > 0 {
+		return path, nil
+	}
+
+	result := make(PathD, len(path))
+	for i, pt := range path {
+		result[i] = PointD{X: -pt.X, Y: pt.Y}
+	}
+	return result, nil
+}
+
+func dxfLine(entity []dxfPair) (PathD, error) {
+	var p0, p1 PointD
+	for _, p := range entity {
+		if p.code != 10 && p.code != 20 && p.code != 11 && p.code != 21 {
+			continue
+		}
+		v, err := dxfFloat(p)
+		if err != nil {
+			return nil, err
+		}
+		switch p.code {
+		case 10:
+			p0.X = v
+		case 20:
+			p0.Y = v
+		case 11:
+			p1.X = v
+		case 21:
+			p1.Y = v
+		}
+	}
+	return stripDuplicatesD(PathD{p0, p1}, false), nil
+}
+
+func dxfArc(entity []dxfPair, tolerance float64, isCircle bool) (PathD, error) {
+	var (
+		center             PointD
+		radius, start, end float64
+	)
+	for _, p := range entity {
+		if p.code != 10 && p.code != 20 && p.code != 40 && p.code != 50 && p.code != 51 {
+			continue
+		}
+		v, err := dxfFloat(p)
+		if err != nil {
+			return nil, err
+		}
+		switch p.code {
+		case 10:
+			center.X = v
+		case 20:
+			center.Y = v
+		case 40:
+			radius = v
+		case 50:
+			start = v
+		case 51:
+			end = v
+		}
+	}
+	if radius <= 0 {
+		return PathD{}, nil
+	}
+
+	if isCircle {
+		path := EllipticalArcD(center, radius, radius, 0, 0, 2*math.Pi, tolerance)
+		return path[:len(path)-1], nil
+	}
+
+	// DXF arcs always run counter-clockwise from the start to the end angle
+	sweep := end - start
+	for sweep <= 0 {
+		sweep += 360
+	}
+	return EllipticalArcD(center, radius, radius, 0, start*math.Pi/180, sweep*math.Pi/180, tolerance), nil
+}
+
+type dxfWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func newDxfWriter(w io.Writer) *dxfWriter {
+	return &dxfWriter{w: bufio.NewWriter(w)}
+}
+
+func (dw *dxfWriter) pair(code int, value string) {
+	if dw.err != nil {
+		return
+	}
+	_, dw.err = fmt.Fprintf(dw.w, "%d\n%s\n", code, value)
+}
+
+func (dw *dxfWriter) float(code int, value float64) {
+	dw.pair(code, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// header starts an R12 file, which unlike later versions needs no handles,
+// subclass markers or OBJECTS section, with a LAYER table of layers.
+func (dw *dxfWriter) header(layers []string) {
+	dw.pair(0, "SECTION")
+	dw.pair(2, "HEADER")
+	dw.pair(9, "$ACADVER")
+	dw.pair(1, "AC1009")
+	dw.pair(0, "ENDSEC")
+
+	unique := make([]string, 0, len(layers))
+	for _, layer := range layers {
+		if !slices.Contains(unique, layer) {
+			unique = append(unique, layer)
+		}
+	}
+	dw.pair(0, "SECTION")
+	dw.pair(2, "TABLES")
+	dw.pair(0, "TABLE")
+	dw.pair(2, "LAYER")
+	dw.pair(70, strconv.Itoa(len(unique)))
+	for _, layer := range unique {
+		dw.pair(0, "LAYER")
+		dw.pair(2, layer)
+		dw.pair(70, "0")
+		dw.pair(62, "7")
+		dw.pair(6, "CONTINUOUS")
+	}
+	dw.pair(0, "ENDTAB")
+	dw.pair(0, "ENDSEC")
+
+	dw.pair(0, "SECTION")
+	dw.pair(2, "ENTITIES")
+}
+
+func (dw *dxfWriter) polyline(layer string, path PathD) {
+	if len(path) < 2 {
+		return
+	}
+	dw.pair(0, "POLYLINE")
+	dw.pair(8, layer)
+	dw.pair(66, "1")
+	dw.float(10, 0)
+	dw.float(20, 0)
+	dw.float(30, 0)
+	dw.pair(70, "1")
+	for _, pt := range path {
+		dw.pair(0, "VERTEX")
+		dw.pair(8, layer)
+		dw.float(10, pt.X)
+		dw.float(20, pt.Y)
+		dw.float(30, 0)
+	}
+	dw.pair(0, "SEQEND")
+	dw.pair(8, layer)
+}
+
+func (dw *dxfWriter) finish() error {
+	dw.pair(0, "ENDSEC")
+	dw.pair(0, "EOF")
+	if dw.err != nil {
+		return dw.err
+	}
+	return dw.w.Flush()
+}
@@ -0,0 +1,254 @@
+package go_clipper2_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+const testDxf = `0
+SECTION
+2
+ENTITIES
+0
+LWPOLYLINE
+8
+PARTS
+90
+4
+70
+1
+10
+0.0
+20
+0.0
+10
+100.0
+20
+0.0
+42
+1.0
+10
+100.0
+20
+100.0
+10
+0.0
+20
+100.0
+0
+POLYLINE
+8
+PARTS
+70
+1
+0
+VERTEX
+10
+200
+20
+0
+0
+VERTEX
+10
+250
+20
+0
+0
+VERTEX
+10
+250
+20
+50
+0
+SEQEND
+0
+LINE
+10
+0
+20
+0
+11
+10
+21
+10
+0
+ARC
+10
+0
+20
+0
+40
+10
+50
+0
+51
+90
+0
+CIRCLE
+10
+500
+20
+500
+40
+20
+0
+TEXT
+1
+ignored
+0
+ENDSEC
+0
+EOF
+`
+
+func TestReadDxfD(t *testing.T) {
+	closed, open, err := goclipper2.ReadDxfD(strings.NewReader(testDxf), 0.01)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(closed))
+	assert.Equal(t, 2, len(open))
+
+	// square with a half circle bulging out of its right edge
+	assert.InDelta(t, 100*100+math.Pi*50*50/2, math.Abs(goclipper2.AreaD(closed[0])), 5)
+	for _, pt := range closed[0] {
+		assert.LessOrEqual(t, pt.X, 150+1e-9)
+	}
+
+	assert.InDelta(t, 1250, math.Abs(goclipper2.AreaD(closed[1])), 1e-9)
+	assert.InDelta(t, math.Pi*20*20, math.Abs(goclipper2.AreaD(closed[2])), 5)
+
+	assert.Equal(t, goclipper2.MakePathD(0, 0, 10, 10), open[0])
+	arc := open[1]
+	assert.InDelta(t, 10, arc[0].X, 1e-9)
+	assert.InDelta(t, 10, arc[len(arc)-1].Y, 1e-9)
+}
+
+func TestReadDxfDExtrusion(t *testing.T) {
+	const mirrored = `0
+SECTION
+2
+ENTITIES
+0
+ARC
+10
+10
+20
+0
+40
+10
+50
+0
+51
+90
+210
+0
+220
+0
+230
+-1
+0
+LWPOLYLINE
+90
+3
+70
+1
+10
+0
+20
+0
+10
+10
+20
+0
+10
+10
+20
+10
+230
+-1.0
+0
+LINE
+10
+5
+20
+5
+11
+15
+21
+5
+230
+-1
+0
+ENDSEC
+0
+EOF
+`
+	closed, open, err := goclipper2.ReadDxfD(strings.NewReader(mirrored), 0.01)
+	assert.NoError(t, err)
+
+	// the arc from 0 to 90 degrees about {10 0} seen from below runs
+	// clockwise about {-10 0} from {-20 0} to {-10 10}
+	arc := open[0]
+	assert.InDelta(t, -20, arc[0].X, 1e-9)
+	assert.InDelta(t, 0, arc[0].Y, 1e-9)
+	assert.InDelta(t, -10, arc[len(arc)-1].X, 1e-9)
+	assert.InDelta(t, 10, arc[len(arc)-1].Y, 1e-9)
+	for _, pt := range arc {
+		assert.LessOrEqual(t, pt.X, -10+1e-9)
+	}
+
+	assert.Equal(t, goclipper2.PathsD{goclipper2.MakePathD(0, 0, -10, 0, -10, 10)}, closed)
+	// lines are in world coordinates whatever their extrusion
+	assert.Equal(t, goclipper2.MakePathD(5, 5, 15, 5), open[1])
+
+	// planes other than Z = 0 aren't supported
+	tilted := strings.Replace(mirrored, "210\n0\n", "210\n1\n", 1)
+	_, _, err = goclipper2.ReadDxfD(strings.NewReader(tilted), 0.01)
+	assert.True(t, errors.Is(err, goclipper2.ErrInvalidDxf))
+}
+
+func TestReadDxfDInvalid(t *testing.T) {
+	_, _, err := goclipper2.ReadDxfD(strings.NewReader("0\nSECTION\nx\nENTITIES\n"), 0)
+	assert.True(t, errors.Is(err, goclipper2.ErrInvalidDxf))
+
+	// whatever follows the end of file marker is ignored
+	for _, trailer := range []string{"\n", "\r\n\r\n", "written by some exporter\n"} {
+		closed, open, err := goclipper2.ReadDxfD(strings.NewReader(testDxf+trailer), 0.01)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(closed))
+		assert.Equal(t, 2, len(open))
+	}
+}
+
+func TestWriteDxfRoundTrip(t *testing.T) {
+	subject := goclipper2.PathsD{
+		goclipper2.MakePathD(0, 0, 100, 0, 100, 100, 0, 100),
+		goclipper2.MakePathD(25, 25, 25, 75, 75, 75, 75, 25),
+	}
+	polytree := goclipper2.BooleanOpPolyTreeD(goclipper2.Union, subject, nil, goclipper2.EvenOdd)
+
+	var buf bytes.Buffer
+	assert.NoError(t, goclipper2.WriteDxfPolyTreeD(&buf, polytree, "OUTER", "HOLES"))
+	assert.Contains(t, buf.String(), "HOLES")
+	// an R12 file, which has no LWPOLYLINE, and a count for every table
+	assert.Contains(t, buf.String(), "$ACADVER\n1\nAC1009\n")
+	assert.NotContains(t, buf.String(), "LWPOLYLINE")
+	assert.Contains(t, buf.String(), "0\nTABLE\n2\nLAYER\n70\n2\n")
+
+	closed, open, err := goclipper2.ReadDxfD(&buf, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(open))
+	assert.Equal(t, 2, len(closed))
+	assert.InDelta(t, 100*100-50*50, math.Abs(goclipper2.AreaD(closed[0]))-math.Abs(goclipper2.AreaD(closed[1])), 1e-9)
+
+	buf.Reset()
+	assert.NoError(t, goclipper2.WriteDxfPathsD(&buf, "CUT", subject))
+	closed, _, err = goclipper2.ReadDxfD(&buf, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, subject, closed)
+}
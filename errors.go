@@ -5,4 +5,6 @@ import "errors"
 var (
 	ErrPrecisionRange         = errors.New("precision is out of range")
 	ErrInvalidRemoveListIndex = errors.New("invalid remove index from list")
+	ErrInvalidSvgPath         = errors.New("invalid svg path data")
+	ErrInvalidDxf             = errors.New("invalid dxf data")
 )
@@ -0,0 +1,229 @@
+package go_clipper2
+
+import (
+	"math"
+	"sort"
+)
+
+type InfillPattern uint8
+
+const (
+	HatchInfill      InfillPattern = iota // parallel lines at the given angle
+	CrossHatchInfill                      // two hatch families at right angles
+	ZigZagInfill                          // hatch lines joined end to end where the link stays inside
+)
+
+type infillSegment struct {
+	path    Path64
+	line    int
+	minX    float64
+	used    bool
+	reverse bool
+}
+
+// Infill64 fills the polygons of a PolyTree64 with lines spaced spacing apart
+// at angle radians from the X axis. The result holds open paths only.
+func Infill64(polytree *PolyTree64, angle, spacing float64, pattern InfillPattern) Paths64 {
+	return infillPolyPath64(polytree.PolyPathBase, angle, spacing, pattern)
+}
+
+// InfillD is the PolyTreeD counterpart of Infill64, spacing is in user units.
+func InfillD(polytree *PolyTreeD, angle, spacing float64, pattern InfillPattern) PathsD {
+	scale := polytree.Scale()
+	if scale == 0 {
+		scale = 1
+	}
+	tmp := infillPolyPath64(polytree.PolyPathBase, angle, spacing*scale, pattern)
+	return ScalePaths64ToPathsD(tmp, 1/scale)
+}
+
+func infillPolyPath64(pp *PolyPathBase, angle, spacing float64, pattern InfillPattern) Paths64 {
+	region := make(Paths64, 0)
+	var walk func(pp *PolyPathBase)
+	walk = func(pp *PolyPathBase) {
+		for _, child := range pp.GetChildren() {
+			region = append(region, child.Polygon())
+			walk(child)
+		}
+	}
+	walk(pp)
+
+	if len(region) == 0 || spacing < 1 {
+		return Paths64{}
+	}
+
+	switch pattern {
+	case CrossHatchInfill:
+		result := hatchRegion64(region, angle, spacing, false)
+		return append(result, hatchRegion64(region, angle+math.Pi/2, spacing, false)...)
+	case ZigZagInfill:
+		return hatchRegion64(region, angle, spacing, true)
+	default:
+		return hatchRegion64(region, angle, spacing, false)
+	}
+}
+
+func hatchRegion64(region Paths64, angle, spacing float64, zigzag bool) Paths64 {
+	sinA, cosA := math.Sincos(angle)
+
+	// bounds of the region in a frame where hatch lines are horizontal
+	minX, minY := math.MaxFloat64, math.MaxFloat64
+	maxX, maxY := -math.MaxFloat64, -math.MaxFloat64
+	bounds := getBounds(region[0])
+	for _, path := range region {
+		for _, pt := range path {
+			x, y := rotatePoint(float64(pt.X), float64(pt.Y), cosA, -sinA)
+			minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+			minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+		}
+		b := getBounds(path)
+		bounds.left, bounds.top = min(bounds.left, b.left), min(bounds.top, b.top)
+		bounds.right, bounds.bottom = max(bounds.right, b.right), max(bounds.bottom, b.bottom)
+	}
+
+	// lines sit half way between multiples of spacing so neighbouring regions
+	// line up and axis aligned edges on the grid don't produce collinear lines
+	first := int(math.Ceil(minY/spacing - 0.5))
+	last := int(math.Floor(maxY/spacing - 0.5))
+	lines := make(Paths64, 0, max(last-first+1, 0))
+	for k := first; k <= last; k++ {
+		y := (float64(k) + 0.5) * spacing
+		x1, y1 := rotatePoint(minX-1, y, cosA, sinA)
+		x2, y2 := rotatePoint(maxX+1, y, cosA, sinA)
+		lines = append(lines, Path64{NewFloatPoint64(x1, y1), NewFloatPoint64(x2, y2)})
+	}
+
+	// cheap trim to the region bounds before the full open path clip
+	bounds.left--
+	bounds.top--
+	bounds.right++
+	bounds.bottom++
+	lines = NewRectClipLines64(bounds).Execute(lines)
+
+	c := NewClipper64()
+	c.AddPaths(lines, Subject, true)
+	c.AddPaths(region, Clip, false)
+	closed, open := make(Paths64, 0), make(Paths64, 0)
+	c.ExecuteOC(Intersection, EvenOdd, &closed, &open)
+
+	segments := make([]*infillSegment, 0, len(open))
+	for _, path := range open {
+		if len(path) < 2 {
+			continue
+		}
+		ax, ay := rotatePoint(float64(path[0].X), float64(path[0].Y), cosA, -sinA)
+		bx, _ := rotatePoint(float64(path[len(path)-1].X), float64(path[len(path)-1].Y), cosA, -sinA)
+		if bx < ax {
+			path = ReversePath(path)
+			ax = bx
+		}
+		segments = append(segments, &infillSegment{
+			path: path,
+			line: int(math.Round(ay/spacing - 0.5)),
+			minX: ax,
+		})
+	}
+
+	sort.SliceStable(segments, func(i, j int) bool {
+		if segments[i].line != segments[j].line {
+			return segments[i].line < segments[j].line
+		}
+		return segments[i].minX < segments[j].minX
+	})
+
+	if !zigzag {
+		result := make(Paths64, 0, len(segments))
+		for _, seg := range segments {
+			result = append(result, seg.path)
+		}
+		return result
+	}
+
+	return zigzagSegments64(segments, region)
+}
+
+// zigzagSegments64 greedily chains hatch segments of successive lines,
+// alternating direction, as long as the connecting link stays inside region.
+func zigzagSegments64(segments []*infillSegment, region Paths64) Paths64 {
+	byLine := make(map[int][]*infillSegment)
+	for _, seg := range segments {
+		byLine[seg.line] = append(byLine[seg.line], seg)
+	}
+
+	result := make(Paths64, 0)
+	for _, start := range segments {
+		if start.used {
+			continue
+		}
+
+		start.used = true
+		chain := append(Path64{}, start.path...)
+		curr := start
+		for {
+			end := chain[len(chain)-1]
+			var (
+				best     *infillSegment
+				bestDist = math.MaxFloat64
+			)
+			for _, cand := range byLine[curr.line+1] {
+				if cand.used {
+					continue
+				}
+				// alternate the direction of travel on every line
+				entry := cand.path[0]
+				if !curr.reverse {
+					entry = cand.path[len(cand.path)-1]
+				}
+				d := math.Hypot(float64(entry.X-end.X), float64(entry.Y-end.Y))
+				if d < bestDist && segmentInsideRegion64(end, entry, region) {
+					best, bestDist = cand, d
+				}
+			}
+			if best == nil {
+				break
+			}
+
+			best.used = true
+			best.reverse = !curr.reverse
+			if best.reverse {
+				chain = append(chain, ReversePath(best.path)...)
+			} else {
+				chain = append(chain, best.path...)
+			}
+			curr = best
+		}
+		result = append(result, StripDuplicates(chain, false))
+	}
+
+	return result
+}
+
+func segmentInsideRegion64(a, b Point64, region Paths64) bool {
+	if a == b {
+		return true
+	}
+	for _, path := range region {
+		cnt := len(path)
+		for i := 0; i < cnt; i++ {
+			if segsIntersect(a, b, path[i], path[(i+1)%cnt], false) {
+				return false
+			}
+		}
+	}
+
+	mid := Point64{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+	inside := false
+	for _, path := range region {
+		switch PointInPolygon(mid, path) {
+		case IsOn:
+			return true
+		case IsInside:
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func rotatePoint(x, y, cosA, sinA float64) (float64, float64) {
+	return x*cosA - y*sinA, x*sinA + y*cosA
+}
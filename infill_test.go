@@ -0,0 +1,108 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfill64(t *testing.T) {
+	region := goclipper2.Paths64{
+		goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+		goclipper2.MakePath64(32, 32, 32, 68, 68, 68, 68, 32),
+	}
+	polytree := goclipper2.BooleanOpPolyTree64(goclipper2.Union, region, nil, goclipper2.EvenOdd)
+
+	tests := []struct {
+		name    string
+		angle   float64
+		pattern goclipper2.InfillPattern
+		count   int
+		length  float64
+	}{
+		{
+			name:    "horizontal hatch",
+			angle:   0,
+			pattern: goclipper2.HatchInfill,
+			count:   10 + 4,
+			length:  10*100 - 4*36,
+		},
+		{
+			name:    "vertical hatch",
+			angle:   math.Pi / 2,
+			pattern: goclipper2.HatchInfill,
+			count:   10 + 4,
+			length:  10*100 - 4*36,
+		},
+		{
+			name:    "cross hatch",
+			angle:   0,
+			pattern: goclipper2.CrossHatchInfill,
+			count:   2 * (10 + 4),
+			length:  2 * (10*100 - 4*36),
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			lines := goclipper2.Infill64(polytree, tt.angle, 10, tt.pattern)
+			assert.Equal(t, tt.count, len(lines))
+			assert.InDelta(t, tt.length, pathsLength64(lines), 2)
+			assertInsideRegion(t, lines, region)
+		})
+	}
+}
+
+func TestInfillZigZag64(t *testing.T) {
+	square := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}
+	polytree := goclipper2.BooleanOpPolyTree64(goclipper2.Union, square, nil, goclipper2.NonZero)
+
+	lines := goclipper2.Infill64(polytree, math.Pi/4, 10, goclipper2.ZigZagInfill)
+	assert.Equal(t, 1, len(lines))
+	assertInsideRegion(t, lines, square)
+
+	// a U shape can't be crossed at its opening, so the zig-zag must split
+	u := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 70, 100, 70, 30, 30, 30, 30, 100, 0, 100)}
+	polytree = goclipper2.BooleanOpPolyTree64(goclipper2.Union, u, nil, goclipper2.NonZero)
+	lines = goclipper2.Infill64(polytree, 0, 10, goclipper2.ZigZagInfill)
+	assert.Greater(t, len(lines), 1)
+	assertInsideRegion(t, lines, u)
+}
+
+func TestInfillD(t *testing.T) {
+	circle := goclipper2.PathsD{goclipper2.EllipseD(goclipper2.PointD{X: 5, Y: 5}, 4, 4, 64)}
+	polytree := goclipper2.BooleanOpPolyTreeD(goclipper2.Union, circle, nil, goclipper2.NonZero)
+
+	lines := goclipper2.InfillD(polytree, 0, 0.5, goclipper2.HatchInfill)
+	assert.Equal(t, 16, len(lines))
+	for _, line := range lines {
+		for _, pt := range line {
+			assert.LessOrEqual(t, math.Hypot(pt.X-5, pt.Y-5), 4.01)
+		}
+	}
+}
+
+func pathsLength64(paths goclipper2.Paths64) float64 {
+	total := 0.0
+	for _, path := range paths {
+		for i := 1; i < len(path); i++ {
+			total += math.Hypot(float64(path[i].X-path[i-1].X), float64(path[i].Y-path[i-1].Y))
+		}
+	}
+	return total
+}
+
+func assertInsideRegion(t *testing.T, lines, region goclipper2.Paths64) {
+	t.Helper()
+
+	outside := goclipper2.NewClipper64()
+	outside.AddPaths(lines, goclipper2.Subject, true)
+	// links may run along the boundary, so allow for it with a small margin
+	outside.AddPaths(goclipper2.InflatePaths64(region, 1, goclipper2.Miter, goclipper2.Polygon), goclipper2.Clip, false)
+	closed, open := goclipper2.Paths64{}, goclipper2.Paths64{}
+	outside.ExecuteOC(goclipper2.Difference, goclipper2.EvenOdd, &closed, &open)
+	assert.InDelta(t, 0, pathsLength64(open), 1)
+}
@@ -1,7 +1,9 @@
 package go_clipper2
 
 import (
+	"cmp"
 	"math"
+	"slices"
 )
 
 const (
@@ -87,6 +89,26 @@ func dotProduct64(pt1, pt2, pt3 Point64) float64 {
 	return float64((pt2.X-pt1.X)*(pt3.X-pt2.X) + (pt2.Y-pt1.Y)*(pt3.Y-pt2.Y))
 }
 
+// crossProductF is CrossProduct, and projectionF the dot product of a->b and
+// a->p, worked out in floats: the int64 products overflow for vectors longer
+// than about 3e9, and squares of them, like squared distances, for ones
+// longer than about 5e4.
+func crossProductF(pt1, pt2, pt3 Point64) float64 {
+	return float64(pt2.X-pt1.X)*float64(pt3.Y-pt2.Y) - float64(pt2.Y-pt1.Y)*float64(pt3.X-pt2.X)
+}
+
+func projectionF(a, b, p Point64) float64 {
+	return float64(p.X-a.X)*float64(b.X-a.X) + float64(p.Y-a.Y)*float64(b.Y-a.Y)
+}
+
+// sortAlong64 sorts pts, which lie on the line through a and b, in the
+// direction a->b.
+func sortAlong64(pts Path64, a, b Point64) {
+	slices.SortFunc(pts, func(p, q Point64) int {
+		return cmp.Compare(projectionF(a, b, p), projectionF(a, b, q))
+	})
+}
+
 func crossProductD(vec1, vec2 PointD) float64 {
 	return vec1.Y*vec2.X - vec2.Y*vec1.X
 }
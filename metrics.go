@@ -0,0 +1,279 @@
+package go_clipper2
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// SecondMoments are the second moments of area of a region about axes through
+// its centroid. Ixx is taken about the x axis (the integral of y² over the
+// region), Iyy about the y axis (x²) and Ixy is the product of inertia (xy).
+type SecondMoments struct {
+	Ixx, Iyy, Ixy float64
+}
+
+// Polar returns the polar moment of area about the centroid.
+func (m SecondMoments) Polar() float64 {
+	return m.Ixx + m.Iyy
+}
+
+// Length64 returns the length of an open path, so without a closing segment.
+func Length64(path Path64) float64 {
+	return LengthD(Path64ToPathD(path))
+}
+
+func LengthD(path PathD) float64 {
+	result := 0.0
+	for i := 1; i < len(path); i++ {
+		result += hypotenuse(path[i].X-path[i-1].X, path[i].Y-path[i-1].Y)
+	}
+	return result
+}
+
+func LengthPaths64(paths Paths64) float64 {
+	result := 0.0
+	for _, path := range paths {
+		result += Length64(path)
+	}
+	return result
+}
+
+func LengthPathsD(paths PathsD) float64 {
+	result := 0.0
+	for _, path := range paths {
+		result += LengthD(path)
+	}
+	return result
+}
+
+// Perimeter64 returns the length of a closed path, including the segment from
+// its last vertex back to its first.
+func Perimeter64(path Path64) float64 {
+	return PerimeterD(Path64ToPathD(path))
+}
+
+func PerimeterD(path PathD) float64 {
+	if len(path) < 2 {
+		return 0
+	}
+	last := path[len(path)-1]
+	return LengthD(path) + hypotenuse(path[0].X-last.X, path[0].Y-last.Y)
+}
+
+// PerimeterPaths64 returns the total length of the outlines of paths, holes
+// included.
+func PerimeterPaths64(paths Paths64) float64 {
+	result := 0.0
+	for _, path := range paths {
+		result += Perimeter64(path)
+	}
+	return result
+}
+
+func PerimeterPathsD(paths PathsD) float64 {
+	result := 0.0
+	for _, path := range paths {
+		result += PerimeterD(path)
+	}
+	return result
+}
+
+// Centroid64 returns the centroid of the area enclosed by path. A path that
+// encloses no area gets the average of its vertices instead.
+func Centroid64(path Path64) PointD {
+	return CentroidD(Path64ToPathD(path))
+}
+
+func CentroidD(path PathD) PointD {
+	return CentroidPathsD(PathsD{path})
+}
+
+// CentroidPaths64 returns the centroid of the region bounded by paths, each
+// path weighted by its signed area, so holes must be oriented opposite to
+// their outer polygons as Clipper returns them.
+func CentroidPaths64(paths Paths64) PointD {
+	return CentroidPathsD(Paths64ToPathsD(paths))
+}
+
+func CentroidPathsD(paths PathsD) PointD {
+	m := areaIntegralsD(paths)
+	if m.area == 0 {
+		return averagePointD(paths)
+	}
+	return PointD{X: m.origin.X + m.sx/m.area, Y: m.origin.Y + m.sy/m.area}
+}
+
+// SecondMoments64 returns the second moments of area of the region bounded by
+// paths, oriented like CentroidPaths64 expects. The moments are positive
+// whichever way round the outer polygons are.
+func SecondMoments64(paths Paths64) SecondMoments {
+	return SecondMomentsD(Paths64ToPathsD(paths))
+}
+
+func SecondMomentsD(paths PathsD) SecondMoments {
+	m := areaIntegralsD(paths)
+	if m.area == 0 {
+		return SecondMoments{}
+	}
+
+	// parallel axis theorem, from the first vertex to the centroid
+	cx, cy := m.sx/m.area, m.sy/m.area
+	result := SecondMoments{
+		Ixx: m.syy - m.area*cy*cy,
+		Iyy: m.sxx - m.area*cx*cx,
+		Ixy: m.sxy - m.area*cx*cy,
+	}
+	if m.area < 0 {
+		result = SecondMoments{Ixx: -result.Ixx, Iyy: -result.Iyy, Ixy: -result.Ixy}
+	}
+	return result
+}
+
+// Compactness64 returns the Polsby-Popper score of the region bounded by
+// paths, 4π·area/perimeter². It's 1 for a circle and tends to 0 for long thin
+// or ragged shapes. Holes add to the perimeter and take from the area.
+func Compactness64(paths Paths64) float64 {
+	return compactness(AreaPaths64(paths), PerimeterPaths64(paths))
+}
+
+func CompactnessD(paths PathsD) float64 {
+	return compactness(AreaPathsD(paths), PerimeterPathsD(paths))
+}
+
+func compactness(area, perimeter float64) float64 {
+	if perimeter == 0 {
+		return 0
+	}
+	return 4 * math.Pi * math.Abs(area) / (perimeter * perimeter)
+}
+
+// Roundness64 returns the area of the region bounded by paths over that of
+// the circle whose diameter is the region's greatest width, 4·area/(π·d²).
+// It's 1 for a circle and unlike Compactness64 isn't lowered by a ragged
+// outline.
+func Roundness64(paths Paths64) float64 {
+	pts := slices.Concat(paths...)
+	return roundness(AreaPaths64(paths), hullDiameterSqrD(Path64ToPathD(convexHull64(pts))))
+}
+
+func RoundnessD(paths PathsD) float64 {
+	pts := slices.Concat(paths...)
+	return roundness(AreaPathsD(paths), hullDiameterSqrD(convexHullD(pts)))
+}
+
+func roundness(area, diameterSqr float64) float64 {
+	if diameterSqr == 0 {
+		return 0
+	}
+	return 4 * math.Abs(area) / (math.Pi * diameterSqr)
+}
+
+// areaIntegrals are the integrals of 1, x, y, x², y² and xy over the region
+// bounded by a set of paths, in coordinates relative to origin so that they
+// stay precise far from (0,0).
+type areaIntegrals struct {
+	origin                      PointD
+	area, sx, sy, sxx, syy, sxy float64
+}
+
+// areaIntegralsD sums the integrals over the triangles each edge makes with
+// origin (Green's theorem), so the sign of each path's share follows its
+// orientation.
+func areaIntegralsD(paths PathsD) areaIntegrals {
+	var result areaIntegrals
+	for _, path := range paths {
+		if len(path) > 0 {
+			result.origin = path[0]
+			break
+		}
+	}
+
+	for _, path := range paths {
+		if len(path) < 3 {
+			continue
+		}
+		prev := path[len(path)-1]
+		for _, pt := range path {
+			x0, y0 := prev.X-result.origin.X, prev.Y-result.origin.Y
+			x1, y1 := pt.X-result.origin.X, pt.Y-result.origin.Y
+			c := x0*y1 - x1*y0
+			result.area += c
+			result.sx += (x0 + x1) * c
+			result.sy += (y0 + y1) * c
+			result.sxx += (x0*x0 + x0*x1 + x1*x1) * c
+			result.syy += (y0*y0 + y0*y1 + y1*y1) * c
+			result.sxy += (x0*y1 + 2*x0*y0 + 2*x1*y1 + x1*y0) * c
+			prev = pt
+		}
+	}
+
+	result.area /= 2
+	result.sx /= 6
+	result.sy /= 6
+	result.sxx /= 12
+	result.syy /= 12
+	result.sxy /= 24
+	return result
+}
+
+func averagePointD(paths PathsD) PointD {
+	var x, y float64
+	cnt := 0
+	for _, path := range paths {
+		for _, pt := range path {
+			x += pt.X
+			y += pt.Y
+			cnt++
+		}
+	}
+	if cnt == 0 {
+		return PointD{}
+	}
+	return PointD{X: x / float64(cnt), Y: y / float64(cnt)}
+}
+
+// hullDiameterSqrD returns the squared distance between the two vertices of
+// a convex hull that are furthest apart.
+func hullDiameterSqrD(hull PathD) float64 {
+	result := 0.0
+	for i := range hull {
+		for j := i + 1; j < len(hull); j++ {
+			result = math.Max(result, sqr(hull[j].X-hull[i].X)+sqr(hull[j].Y-hull[i].Y))
+		}
+	}
+	return result
+}
+
+// convexHullD is convexHull64 for PathD.
+func convexHullD(path PathD) PathD {
+	pts := make(PathD, len(path))
+	copy(pts, path)
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].X != pts[j].X {
+			return pts[i].X < pts[j].X
+		}
+		return pts[i].Y < pts[j].Y
+	})
+	if len(pts) < 3 {
+		return pts
+	}
+
+	result := make(PathD, 0, len(pts)+1)
+	for pass := 0; pass < 2; pass++ {
+		start := len(result)
+		for _, pt := range pts {
+			for len(result) >= start+2 {
+				a, b := result[len(result)-2], result[len(result)-1]
+				if (b.X-a.X)*(pt.Y-b.Y)-(b.Y-a.Y)*(pt.X-b.X) > 0 {
+					break
+				}
+				result = result[:len(result)-1]
+			}
+			result = append(result, pt)
+		}
+		result = result[:len(result)-1]
+		slices.Reverse(pts)
+	}
+	return result
+}
@@ -0,0 +1,136 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLengthAndPerimeter64(t *testing.T) {
+	path := goclipper2.MakePath64(0, 0, 30, 0, 30, 40)
+	assert.Equal(t, 70.0, goclipper2.Length64(path))
+	assert.Equal(t, 120.0, goclipper2.Perimeter64(path))
+	assert.Equal(t, 0.0, goclipper2.Perimeter64(goclipper2.MakePath64(5, 5)))
+
+	paths := goclipper2.Paths64{path, goclipper2.MakePath64(0, 0, 0, 10)}
+	assert.Equal(t, 80.0, goclipper2.LengthPaths64(paths))
+	assert.Equal(t, 140.0, goclipper2.PerimeterPaths64(paths))
+
+	pathD := goclipper2.MakePathD(0, 0, 0.3, 0, 0.3, 0.4)
+	assert.InDelta(t, 0.7, goclipper2.LengthD(pathD), 1e-12)
+	assert.InDelta(t, 1.2, goclipper2.PerimeterD(pathD), 1e-12)
+}
+
+func TestCentroidAndMoments64(t *testing.T) {
+	tests := []struct {
+		name     string
+		paths    goclipper2.Paths64
+		centroid goclipper2.PointD
+		moments  goclipper2.SecondMoments
+	}{
+		{
+			name:     "rectangle",
+			paths:    goclipper2.Paths64{goclipper2.MakePath64(10, 20, 70, 20, 70, 50, 10, 50)},
+			centroid: goclipper2.PointD{X: 40, Y: 35},
+			moments:  goclipper2.SecondMoments{Ixx: 60 * 30 * 30 * 30 / 12.0, Iyy: 30 * 60 * 60 * 60 / 12.0},
+		},
+		{
+			name:     "reversed rectangle",
+			paths:    goclipper2.Paths64{goclipper2.MakePath64(10, 50, 70, 50, 70, 20, 10, 20)},
+			centroid: goclipper2.PointD{X: 40, Y: 35},
+			moments:  goclipper2.SecondMoments{Ixx: 60 * 30 * 30 * 30 / 12.0, Iyy: 30 * 60 * 60 * 60 / 12.0},
+		},
+		{
+			name: "off centre hole",
+			paths: goclipper2.Paths64{
+				goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+				goclipper2.MakePath64(50, 0, 50, 100, 100, 100, 100, 0),
+			},
+			centroid: goclipper2.PointD{X: 25, Y: 50},
+			moments:  goclipper2.SecondMoments{Ixx: 50 * 100 * 100 * 100 / 12.0, Iyy: 100 * 50 * 50 * 50 / 12.0},
+		},
+		{
+			name:     "right triangle far from the origin",
+			paths:    goclipper2.Paths64{goclipper2.MakePath64(1e9, 1e9, 1e9+60, 1e9, 1e9, 1e9+30)},
+			centroid: goclipper2.PointD{X: 1e9 + 20, Y: 1e9 + 10},
+			moments:  goclipper2.SecondMoments{Ixx: 60 * 30 * 30 * 30 / 36.0, Iyy: 30 * 60 * 60 * 60 / 36.0, Ixy: -60 * 60 * 30 * 30 / 72.0},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			centroid := goclipper2.CentroidPaths64(tt.paths)
+			assert.InDelta(t, tt.centroid.X, centroid.X, 1e-6)
+			assert.InDelta(t, tt.centroid.Y, centroid.Y, 1e-6)
+
+			moments := goclipper2.SecondMoments64(tt.paths)
+			assert.InDelta(t, tt.moments.Ixx, moments.Ixx, 1e-3)
+			assert.InDelta(t, tt.moments.Iyy, moments.Iyy, 1e-3)
+			assert.InDelta(t, tt.moments.Ixy, moments.Ixy, 1e-3)
+			assert.InDelta(t, tt.moments.Ixx+tt.moments.Iyy, moments.Polar(), 1e-3)
+		})
+	}
+
+	// no area
+	assert.Equal(t, goclipper2.PointD{X: 5, Y: 0}, goclipper2.Centroid64(goclipper2.MakePath64(0, 0, 10, 0)))
+	assert.Equal(t, goclipper2.SecondMoments{}, goclipper2.SecondMoments64(nil))
+
+	centroid := goclipper2.CentroidD(goclipper2.MakePathD(0, 0, 0.6, 0, 0, 0.3))
+	assert.InDelta(t, 0.2, centroid.X, 1e-12)
+	assert.InDelta(t, 0.1, centroid.Y, 1e-12)
+}
+
+func TestCompactnessAndRoundness64(t *testing.T) {
+	circle := goclipper2.Paths64{goclipper2.Ellipse64(goclipper2.Point64{}, 1000, 1000, 360)}
+	square := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}
+	strip := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 1000, 0, 1000, 10, 0, 10)}
+
+	assert.InDelta(t, 1, goclipper2.Compactness64(circle), 1e-3)
+	assert.InDelta(t, math.Pi/4, goclipper2.Compactness64(square), 1e-9)
+	assert.Less(t, goclipper2.Compactness64(strip), 0.05)
+
+	assert.InDelta(t, 1, goclipper2.Roundness64(circle), 2e-3)
+	assert.InDelta(t, 2/math.Pi, goclipper2.Roundness64(square), 1e-9)
+	assert.Less(t, goclipper2.Roundness64(strip), 0.05)
+
+	squareD := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 0.1, 0, 0.1, 0.1, 0, 0.1)}
+	assert.InDelta(t, math.Pi/4, goclipper2.CompactnessD(squareD), 1e-9)
+	assert.InDelta(t, 2/math.Pi, goclipper2.RoundnessD(squareD), 1e-9)
+
+	// a ragged outline is less compact but just as round
+	ragged := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 50, 0, 50, 5, 55, 5, 55, 0, 100, 0, 100, 100, 0, 100)}
+	assert.Less(t, goclipper2.Compactness64(ragged), goclipper2.Compactness64(square))
+	assert.InDelta(t, goclipper2.Roundness64(square), goclipper2.Roundness64(ragged), 0.01)
+}
+
+func TestPolyTreeMetrics(t *testing.T) {
+	polytree := goclipper2.BooleanOpPolyTree64(goclipper2.Union, nestedSquares64(), nil, goclipper2.EvenOdd)
+
+	for pp := range polytree.All() {
+		if pp.Level() != 1 || len(pp.GetChildren()) == 0 {
+			continue
+		}
+		// the 100 square less the 80 one
+		assert.Equal(t, 4*100+4*80.0, pp.Perimeter())
+		assert.Equal(t, goclipper2.PointD{X: 50, Y: 50}, pp.Centroid())
+		assert.InDelta(t, (math.Pow(100, 4)-math.Pow(80, 4))/12, pp.SecondMoments().Ixx, 1e-6)
+		assert.InDelta(t, 4*math.Pi*(100*100-80*80)/(720*720), pp.Compactness(), 1e-9)
+		assert.InDelta(t, 4*(100*100-80*80)/(math.Pi*2*100*100), pp.Roundness(), 1e-9)
+
+		hole := pp.GetChildren()[0]
+		assert.Equal(t, 4*80+4*60.0, hole.Perimeter())
+		assert.InDelta(t, -(math.Pow(80, 4)-math.Pow(60, 4))/12, hole.SecondMoments().Iyy, 1e-6)
+	}
+
+	polytreeD := goclipper2.BooleanOpPolyTreeD(goclipper2.Union, goclipper2.PathsD{goclipper2.MakePathD(0, 0, 2, 0, 2, 1, 0, 1)}, nil, goclipper2.NonZero)
+	outer := polytreeD.Child(0)
+	assert.InDelta(t, 6, outer.PerimeterD(), 1e-9)
+	assert.InDelta(t, 1, outer.CentroidD().X, 1e-9)
+	assert.InDelta(t, 0.5, outer.CentroidD().Y, 1e-9)
+	assert.InDelta(t, 2.0/12, outer.SecondMomentsD().Ixx, 1e-9)
+	assert.InDelta(t, 8.0/12, outer.SecondMomentsD().Iyy, 1e-9)
+	assert.Equal(t, 0.0, polytreeD.Perimeter())
+}
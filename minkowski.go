@@ -5,7 +5,7 @@ import (
 )
 
 func MinkowskiSum64(pattern, path Path64, isClosed bool) Paths64 {
-	return UnionPaths64(minkowskiInternal(pattern, path, true, isClosed), NonZero)
+	return minkowski(pattern, path, true, isClosed)
 }
 
 func MinkowskiSumD(pattern, path PathD, isClosed bool, precisionV ...int) PathsD {
@@ -18,12 +18,12 @@ func MinkowskiSumD(pattern, path PathD, isClosed bool, precisionV ...int) PathsD
 	sPattern := ScalePathDToPath64(pattern, scale)
 	sPath := ScalePathDToPath64(path, scale)
 
-	tmp := UnionPaths64(minkowskiInternal(sPattern, sPath, true, isClosed), NonZero)
+	tmp := minkowski(sPattern, sPath, true, isClosed)
 	return ScalePaths64ToPathsD(tmp, 1/scale)
 }
 
 func MinkowskiDiff64(pattern, path Path64, isClosed bool) Paths64 {
-	return UnionPaths64(minkowskiInternal(pattern, path, false, isClosed), NonZero)
+	return minkowski(pattern, path, false, isClosed)
 }
 
 func MinkowskiDiffD(pattern, path PathD, isClosed bool, precisionV ...int) PathsD {
@@ -36,10 +36,82 @@ func MinkowskiDiffD(pattern, path PathD, isClosed bool, precisionV ...int) Paths
 	sPattern := ScalePathDToPath64(pattern, scale)
 	sPath := ScalePathDToPath64(path, scale)
 
-	tmp := UnionPaths64(minkowskiInternal(sPattern, sPath, false, isClosed), NonZero)
+	tmp := minkowski(sPattern, sPath, false, isClosed)
 	return ScalePaths64ToPathsD(tmp, 1/scale)
 }
 
+// MinkowskiSumPaths64 is MinkowskiSum64 for several pattern paths and paths:
+// the union of MinkowskiSum64 over every pair of them, after both operands
+// are normalized with fillRule (paths only when isClosed). Like
+// MinkowskiSum64 it sweeps the pattern along the outlines of paths, holes
+// included, so it isn't filled where paths are wider than the pattern.
+// NoFitPolygon64 gives the filled difference.
+func MinkowskiSumPaths64(pattern, paths Paths64, isClosed bool, fillRule FillRule) Paths64 {
+	return UnionPaths64(minkowskiPairsInternal(pattern, paths, true, isClosed, fillRule), NonZero)
+}
+
+// MinkowskiSumPathsD is MinkowskiSumPaths64 for PathsD, with coordinates
+// rounded to precisionV decimal places (2 by default).
+func MinkowskiSumPathsD(pattern, paths PathsD, isClosed bool, fillRule FillRule, precisionV ...int) PathsD {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+
+	scale := math.Pow(10, float64(precision))
+	sPattern := ScalePathsDToPaths64(pattern, scale)
+	sPaths := ScalePathsDToPaths64(paths, scale)
+
+	tmp := UnionPaths64(minkowskiPairsInternal(sPattern, sPaths, true, isClosed, fillRule), NonZero)
+	return ScalePaths64ToPathsD(tmp, 1/scale)
+}
+
+// MinkowskiSumPolyTree64 is MinkowskiSumPaths64 returning a PolyTree64.
+func MinkowskiSumPolyTree64(pattern, paths Paths64, isClosed bool, fillRule FillRule) *PolyTree64 {
+	return BooleanOpPolyTree64(Union, minkowskiPairsInternal(pattern, paths, true, isClosed, fillRule), nil, NonZero)
+}
+
+// MinkowskiSumPolyTreeD is MinkowskiSumPathsD returning a PolyTreeD.
+func MinkowskiSumPolyTreeD(pattern, paths PathsD, isClosed bool, fillRule FillRule, precisionV ...int) *PolyTreeD {
+	c, scale := newMinkowskiClipperD(precisionV...)
+	tmp := minkowskiPairsInternal(ScalePathsDToPaths64(pattern, scale), ScalePathsDToPaths64(paths, scale), true, isClosed, fillRule)
+	return c.polyTree(tmp)
+}
+
+// MinkowskiDiffPaths64 is MinkowskiSumPaths64 with the pattern reflected
+// through the origin, the union of MinkowskiDiff64 over every pair.
+func MinkowskiDiffPaths64(pattern, paths Paths64, isClosed bool, fillRule FillRule) Paths64 {
+	return UnionPaths64(minkowskiPairsInternal(pattern, paths, false, isClosed, fillRule), NonZero)
+}
+
+// MinkowskiDiffPathsD is MinkowskiDiffPaths64 for PathsD, with coordinates
+// rounded to precisionV decimal places (2 by default).
+func MinkowskiDiffPathsD(pattern, paths PathsD, isClosed bool, fillRule FillRule, precisionV ...int) PathsD {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+
+	scale := math.Pow(10, float64(precision))
+	sPattern := ScalePathsDToPaths64(pattern, scale)
+	sPaths := ScalePathsDToPaths64(paths, scale)
+
+	tmp := UnionPaths64(minkowskiPairsInternal(sPattern, sPaths, false, isClosed, fillRule), NonZero)
+	return ScalePaths64ToPathsD(tmp, 1/scale)
+}
+
+// MinkowskiDiffPolyTree64 is MinkowskiDiffPaths64 returning a PolyTree64.
+func MinkowskiDiffPolyTree64(pattern, paths Paths64, isClosed bool, fillRule FillRule) *PolyTree64 {
+	return BooleanOpPolyTree64(Union, minkowskiPairsInternal(pattern, paths, false, isClosed, fillRule), nil, NonZero)
+}
+
+// MinkowskiDiffPolyTreeD is MinkowskiDiffPathsD returning a PolyTreeD.
+func MinkowskiDiffPolyTreeD(pattern, paths PathsD, isClosed bool, fillRule FillRule, precisionV ...int) *PolyTreeD {
+	c, scale := newMinkowskiClipperD(precisionV...)
+	tmp := minkowskiPairsInternal(ScalePathsDToPaths64(pattern, scale), ScalePathsDToPaths64(paths, scale), false, isClosed, fillRule)
+	return c.polyTree(tmp)
+}
+
 func minkowskiInternal(pattern Path64, path Path64, isSum bool, isClosed bool) Paths64 {
 	delta := 1
 	if isClosed {
@@ -89,3 +161,106 @@ func minkowskiInternal(pattern Path64, path Path64, isSum bool, isClosed bool) P
 
 	return result
 }
+
+// minkowskiPairsInternal returns minkowski of every pattern path with every
+// path, unmerged. Both operands are first normalized with fillRule, paths
+// only when isClosed.
+func minkowskiPairsInternal(pattern, paths Paths64, isSum, isClosed bool, fillRule FillRule) Paths64 {
+	pattern = UnionPaths64(pattern, fillRule)
+	if isClosed {
+		paths = UnionPaths64(paths, fillRule)
+	}
+
+	result := make(Paths64, 0)
+	for _, pat := range pattern {
+		for _, path := range paths {
+			if len(path) > 0 {
+				result = append(result, minkowski(pat, path, isSum, isClosed)...)
+			}
+		}
+	}
+	return result
+}
+
+// minkowskiPathsInternal returns the filled Minkowski sum (or difference) of
+// two polygon sets with holes as unmerged, positively oriented paths. Both
+// operands are first normalized with fillRule. The result is the union of the
+// edge/edge quads of every path pair, each polygon of paths translated by a
+// vertex of every pattern path, and the pattern translated by a vertex of
+// every path.
+func minkowskiPathsInternal(pattern, paths Paths64, isSum bool, fillRule FillRule) Paths64 {
+	pattern = UnionPaths64(pattern, fillRule)
+	paths = UnionPaths64(paths, fillRule)
+	if len(pattern) == 0 || len(paths) == 0 {
+		return Paths64{}
+	}
+
+	// the filled sum of two convex polygons without holes is just their merged outline
+	filledConvex := !hasNegativePath64(pattern) && !hasNegativePath64(paths)
+
+	result := make(Paths64, 0)
+	for _, pat := range pattern {
+		for _, path := range paths {
+			if filledConvex {
+				if p, q, ok := convexOperands64(pat, path, isSum); ok {
+					result = append(result, convexMinkowskiSum64(p, q))
+					continue
+				}
+			}
+			result = append(result, minkowskiInternal(pat, path, isSum, true)...)
+		}
+	}
+
+	for _, pat := range pattern {
+		dx, dy := pat[0].X, pat[0].Y
+		if !isSum {
+			dx, dy = -dx, -dy
+		}
+		result = append(result, TranslatePaths64(paths, dx, dy)...)
+	}
+
+	for _, path := range paths {
+		for _, pat := range pattern {
+			moved := make(Path64, len(pat))
+			for i, pt := range pat {
+				if isSum {
+					moved[i] = Point64{X: path[0].X + pt.X, Y: path[0].Y + pt.Y}
+				} else {
+					moved[i] = Point64{X: path[0].X - pt.X, Y: path[0].Y - pt.Y}
+				}
+			}
+			result = append(result, moved)
+		}
+	}
+
+	return result
+}
+
+func hasNegativePath64(paths Paths64) bool {
+	for _, path := range paths {
+		if !IsPositive64(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func newMinkowskiClipperD(precisionV ...int) (*clipperD, float64) {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+
+	c := NewClipperD(precision)
+	return c, c.scale
+}
+
+// polyTree unions already scaled paths into a PolyTreeD.
+func (c *clipperD) polyTree(paths Paths64) *PolyTreeD {
+	polytree := NewPolyTreeD()
+	c.addPaths(paths, Subject, false)
+
+	openPaths := make(PathsD, 0)
+	c.ExecutePolyTreeD(Union, NonZero, polytree, &openPaths)
+	return polytree
+}
@@ -0,0 +1,294 @@
+package go_clipper2
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// minkowski returns the union of the minkowskiInternal quads. When the
+// pattern is convex the quads aren't needed: the pattern swept along a path
+// edge is the convex hull of its copies at both ends, and the only part of
+// those hulls the quads leave uncovered is where the whole path fits inside
+// the pattern. If the closed path is convex too, the outline is the
+// edge-slope merge of the two polygons, which takes linear time.
+func minkowski(pattern, path Path64, isSum, isClosed bool) Paths64 {
+	if isClosed {
+		if result, ok := minkowskiConvex(pattern, path, isSum); ok {
+			return UnionPaths64(result, NonZero)
+		}
+	}
+	if hulls, inside, ok := minkowskiConvexPattern(pattern, path, isSum, isClosed); ok {
+		if len(inside) == 0 {
+			return UnionPaths64(hulls, NonZero)
+		}
+		return DifferenceWithClipPaths64(hulls, Paths64{inside}, NonZero)
+	}
+	return UnionPaths64(minkowskiInternal(pattern, path, isSum, isClosed), NonZero)
+}
+
+// minkowskiConvexPattern returns the hulls swept by a convex pattern along
+// every edge of path, and the positions at which path lies inside the
+// pattern, or false if the pattern isn't convex.
+func minkowskiConvexPattern(pattern, path Path64, isSum, isClosed bool) (Paths64, Path64, bool) {
+	q, ok := convexCCW64(pattern)
+	if !ok {
+		return nil, nil, false
+	}
+	path = StripDuplicates(path, isClosed)
+	if len(path) < 2 {
+		return nil, nil, false
+	}
+	if !isSum {
+		q = negatePath64(q)
+	}
+
+	hulls := make(Paths64, 0, len(path))
+	i := 1
+	if isClosed {
+		i = 0
+	}
+	for ; i < len(path); i++ {
+		prev := path[len(path)-1]
+		if i > 0 {
+			prev = path[i-1]
+		}
+		hulls = append(hulls, convexMinkowskiSum64(Path64{prev, path[i]}, q))
+	}
+
+	// the pattern is convex, so it holds the path if it holds the path's hull
+	return hulls, convexErode64(q, negatePath64(convexHull64(path))), true
+}
+
+// minkowskiConvex returns the unmerged outline and holes of the sweep of
+// pattern along the closed path, or false if either isn't convex.
+func minkowskiConvex(pattern, path Path64, isSum bool) (Paths64, bool) {
+	p, q, ok := convexOperands64(pattern, path, isSum)
+	if !ok {
+		return nil, false
+	}
+
+	result := Paths64{convexMinkowskiSum64(p, q)}
+	// positions where the pattern lies inside the path, or the path inside the pattern
+	if hole := convexErode64(p, negatePath64(q)); len(hole) > 2 {
+		result = append(result, ReversePath(hole))
+	}
+	if hole := convexErode64(q, negatePath64(p)); len(hole) > 2 {
+		result = append(result, ReversePath(hole))
+	}
+	return result, true
+}
+
+// convexOperands64 returns path and the (reflected if !isSum) pattern as
+// positively oriented convex polygons, or false if either isn't convex.
+func convexOperands64(pattern, path Path64, isSum bool) (p, q Path64, ok bool) {
+	if q, ok = convexCCW64(pattern); !ok {
+		return nil, nil, false
+	}
+	if p, ok = convexCCW64(path); !ok {
+		return nil, nil, false
+	}
+	if !isSum {
+		q = negatePath64(q)
+	}
+	return p, q, true
+}
+
+// convexCCW64 returns a copy of path without duplicate or collinear vertices,
+// oriented positively, if path is a strictly convex polygon.
+func convexCCW64(path Path64) (Path64, bool) {
+	path = TrimCollinear64(StripDuplicates(path, true), false)
+	cnt := len(path)
+	if cnt < 3 {
+		return nil, false
+	}
+
+	sign := 0
+	turn := 0.0
+	for i := 0; i < cnt; i++ {
+		prev := path[(i+cnt-1)%cnt]
+		curr := path[i]
+		next := path[(i+1)%cnt]
+		cross := CrossProduct(prev, curr, next)
+		s := 1
+		if cross < 0 {
+			s = -1
+		}
+		if sign == 0 {
+			sign = s
+		} else if s != sign {
+			return nil, false
+		}
+		turn += math.Atan2(cross, dotProduct64(prev, curr, next))
+	}
+
+	// a star polygon turns consistently too, but more than once around
+	if math.Abs(turn) > 3*math.Pi {
+		return nil, false
+	}
+
+	if sign < 0 {
+		path = ReversePath(path)
+	}
+	return path, true
+}
+
+// convexHull64 returns the positively oriented convex hull of path
+// (Andrew's monotone chain).
+func convexHull64(path Path64) Path64 {
+	pts := make(Path64, len(path))
+	copy(pts, path)
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].X != pts[j].X {
+			return pts[i].X < pts[j].X
+		}
+		return pts[i].Y < pts[j].Y
+	})
+	if len(pts) < 3 {
+		return StripDuplicates(pts, true)
+	}
+
+	result := make(Path64, 0, len(pts)+1)
+	for pass := 0; pass < 2; pass++ {
+		start := len(result)
+		for _, pt := range pts {
+			for len(result) >= start+2 && CrossProduct(result[len(result)-2], result[len(result)-1], pt) <= 0 {
+				result = result[:len(result)-1]
+			}
+			result = append(result, pt)
+		}
+		// the last point of each chain starts the other one
+		result = result[:len(result)-1]
+		slices.Reverse(pts)
+	}
+	return result
+}
+
+func negatePath64(path Path64) Path64 {
+	result := make(Path64, len(path))
+	for i, pt := range path {
+		result[i] = Point64{X: -pt.X, Y: -pt.Y}
+	}
+	return result
+}
+
+// lowestVertex returns the index of the vertex with the smallest Y, then X.
+func lowestVertex(path Path64) int {
+	result := 0
+	for i, pt := range path {
+		if pt.Y < path[result].Y || (pt.Y == path[result].Y && pt.X < path[result].X) {
+			result = i
+		}
+	}
+	return result
+}
+
+// convexMinkowskiSum64 merges the edges of two positively oriented convex
+// polygons by slope, which takes len(p)+len(q) steps.
+func convexMinkowskiSum64(p, q Path64) Path64 {
+	n, m := len(p), len(q)
+	i0, j0 := lowestVertex(p), lowestVertex(q)
+
+	result := make(Path64, 0, n+m)
+	i, j := 0, 0
+	for i < n || j < m {
+		a := p[(i0+i)%n]
+		b := q[(j0+j)%m]
+		result = append(result, Point64{X: a.X + b.X, Y: a.Y + b.Y})
+
+		if i == n {
+			j++
+			continue
+		}
+		if j == m {
+			i++
+			continue
+		}
+
+		ea := p[(i0+i+1)%n]
+		eb := q[(j0+j+1)%m]
+		cross := float64(ea.X-a.X)*float64(eb.Y-b.Y) - float64(ea.Y-a.Y)*float64(eb.X-b.X)
+		if cross >= 0 {
+			i++
+		}
+		if cross <= 0 {
+			j++
+		}
+	}
+
+	return result
+}
+
+// convexErode64 returns the positions x at which b+x lies inside a. Both
+// polygons must be convex and positively oriented. Every edge of a is moved
+// inwards by the support vertex of b, the vertex furthest along its outward
+// normal; as the normals turn monotonically that vertex only moves forwards.
+func convexErode64(a, b Path64) Path64 {
+	n, m := len(a), len(b)
+
+	support := func(nx, ny float64, j int) int {
+		best := nx*float64(b[j].X) + ny*float64(b[j].Y)
+		for k := 0; k < m; k++ {
+			next := (j + 1) % m
+			d := nx*float64(b[next].X) + ny*float64(b[next].Y)
+			if d < best {
+				break
+			}
+			j, best = next, d
+		}
+		return j
+	}
+
+	// start with the support vertex of the first edge found by a full scan
+	nx, ny := float64(a[1].Y-a[0].Y), -float64(a[1].X-a[0].X)
+	j := 0
+	for k := 1; k < m; k++ {
+		if nx*float64(b[k].X)+ny*float64(b[k].Y) > nx*float64(b[j].X)+ny*float64(b[j].Y) {
+			j = k
+		}
+	}
+
+	poly := Path64ToPathD(TranslatePath64(a, -b[j].X, -b[j].Y))
+	for i := 0; i < n && len(poly) > 2; i++ {
+		a1, a2 := a[i], a[(i+1)%n]
+		nx, ny = float64(a2.Y-a1.Y), -float64(a2.X-a1.X)
+		j = support(nx, ny, j)
+		p1 := PointD{X: float64(a1.X - b[j].X), Y: float64(a1.Y - b[j].Y)}
+		p2 := PointD{X: float64(a2.X - b[j].X), Y: float64(a2.Y - b[j].Y)}
+		poly = clipHalfPlaneD(poly, p1, p2)
+	}
+
+	if len(poly) < 3 {
+		return nil
+	}
+	result := StripDuplicates(pathDToPath64Rounded(poly), true)
+	if Area64(result) <= 0 {
+		return nil
+	}
+	return result
+}
+
+// clipHalfPlaneD keeps the part of poly on the left of the line p1->p2.
+func clipHalfPlaneD(poly PathD, p1, p2 PointD) PathD {
+	dir := PointD{X: p2.X - p1.X, Y: p2.Y - p1.Y}
+	side := func(pt PointD) float64 {
+		return dir.X*(pt.Y-p1.Y) - dir.Y*(pt.X-p1.X)
+	}
+
+	cnt := len(poly)
+	result := make(PathD, 0, cnt+1)
+	prev := poly[cnt-1]
+	prevSide := side(prev)
+	for _, curr := range poly {
+		currSide := side(curr)
+		if (prevSide >= 0) != (currSide >= 0) {
+			t := prevSide / (prevSide - currSide)
+			result = append(result, PointD{X: prev.X + t*(curr.X-prev.X), Y: prev.Y + t*(curr.Y-prev.Y)})
+		}
+		if currSide >= 0 {
+			result = append(result, curr)
+		}
+		prev, prevSide = curr, currSide
+	}
+	return result
+}
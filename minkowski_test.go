@@ -2,10 +2,10 @@ package go_clipper2_test
 
 import (
 	"fmt"
-	"reflect"
 	"testing"
 
 	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMinkowskiSum64(t *testing.T) {
@@ -32,9 +32,7 @@ func TestMinkowskiSum64(t *testing.T) {
 	for i, tt := range tests {
 		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
 			results := goclipper2.MinkowskiSum64(pattern, tt.path, tt.isClosed)
-			if !reflect.DeepEqual(results, tt.expect) {
-				t.Errorf("got %v, expect %v", results, tt.expect)
-			}
+			assertSamePaths64(t, tt.expect, results)
 		})
 	}
 }
@@ -63,9 +61,7 @@ func TestMinkowskiSumD(t *testing.T) {
 	for i, tt := range tests {
 		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
 			results := goclipper2.MinkowskiSumD(pattern, tt.path, tt.isClosed)
-			if !reflect.DeepEqual(results, tt.expect) {
-				t.Errorf("got %v, \n\t\t\t\t expect %v", results, tt.expect)
-			}
+			assertSamePathsD(t, tt.expect, results)
 		})
 	}
 }
@@ -94,9 +90,7 @@ func TestMinkowskiDiff64(t *testing.T) {
 	for i, tt := range tests {
 		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
 			results := goclipper2.MinkowskiDiff64(pattern, tt.path, tt.isClosed)
-			if !reflect.DeepEqual(results, tt.expect) {
-				t.Errorf("got %v, expect %v", results, tt.expect)
-			}
+			assertSamePaths64(t, tt.expect, results)
 		})
 	}
 }
@@ -125,9 +119,245 @@ func TestMinkowskiDiffD(t *testing.T) {
 	for i, tt := range tests {
 		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
 			results := goclipper2.MinkowskiDiffD(pattern, tt.path, tt.isClosed)
-			if !reflect.DeepEqual(results, tt.expect) {
-				t.Errorf("got %v, expect %v", results, tt.expect)
+			assertSamePathsD(t, tt.expect, results)
+		})
+	}
+}
+
+func TestMinkowskiSumPaths64(t *testing.T) {
+	var (
+		brush = goclipper2.Paths64{goclipper2.MakePath64(-10, -10, 10, -10, 10, 10, -10, 10)}
+		frame = goclipper2.Paths64{
+			goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+			goclipper2.MakePath64(30, 30, 30, 70, 70, 70, 70, 30),
+		}
+	)
+
+	tests := []struct {
+		name     string
+		pattern  goclipper2.Paths64
+		paths    goclipper2.Paths64
+		isClosed bool
+		area     float64
+		outers   int
+		holes    int
+	}{
+		{
+			// the brush swept along the outline leaves the middle open
+			name:     "square with square",
+			pattern:  brush,
+			paths:    goclipper2.Paths64{frame[0]},
+			isClosed: true,
+			area:     120*120 - 80*80,
+			outers:   1,
+			holes:    1,
+		},
+		{
+			name:     "path with hole",
+			pattern:  brush,
+			paths:    frame,
+			isClosed: true,
+			area:     120*120 - 80*80 + 60*60 - 20*20,
+			outers:   1,
+			holes:    1,
+		},
+		{
+			name:     "pattern with hole",
+			pattern:  frame,
+			paths:    brush,
+			isClosed: true,
+			area:     120*120 - 80*80 + 60*60 - 20*20,
+			outers:   1,
+			holes:    1,
+		},
+		{
+			name:     "disjoint paths",
+			pattern:  brush,
+			paths:    goclipper2.Paths64{goclipper2.MakePath64(0, 0, 10, 0, 10, 10, 0, 10), goclipper2.MakePath64(100, 0, 110, 0, 110, 10, 100, 10)},
+			isClosed: true,
+			area:     2 * (30*30 - 10*10),
+			outers:   2,
+			holes:    2,
+		},
+		{
+			name:     "open polyline",
+			pattern:  brush,
+			paths:    goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100)},
+			isClosed: false,
+			area:     120*20 + 100*20,
+			outers:   1,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			results := goclipper2.MinkowskiSumPaths64(tt.pattern, tt.paths, tt.isClosed, goclipper2.NonZero)
+			assert.InDelta(t, tt.area, goclipper2.AreaPaths64(results), 1e-9)
+
+			polytree := goclipper2.MinkowskiSumPolyTree64(tt.pattern, tt.paths, tt.isClosed, goclipper2.NonZero)
+			assert.Equal(t, tt.outers, len(polytree.GetChildren()))
+			holes := 0
+			for _, outer := range polytree.GetChildren() {
+				holes += len(outer.GetChildren())
 			}
+			assert.Equal(t, tt.holes, holes)
 		})
 	}
 }
+
+func TestMinkowskiPathsMatchSinglePath64(t *testing.T) {
+	var (
+		circle = goclipper2.Ellipse64(goclipper2.Point64{X: 10, Y: -20}, 30, 30, 0)
+		notch  = goclipper2.MakePath64(-20, -20, 20, -20, 20, 20, 0, 5, -20, 20)
+		lShape = goclipper2.MakePath64(0, 0, 200, 0, 200, 60, 60, 60, 60, 200, 0, 200)
+	)
+
+	for _, pattern := range []goclipper2.Path64{circle, notch} {
+		for _, isClosed := range []bool{true, false} {
+			sum := goclipper2.MinkowskiSumPaths64(goclipper2.Paths64{pattern}, goclipper2.Paths64{lShape}, isClosed, goclipper2.NonZero)
+			assertSamePaths64(t, goclipper2.MinkowskiSum64(pattern, lShape, isClosed), sum)
+
+			diff := goclipper2.MinkowskiDiffPaths64(goclipper2.Paths64{pattern}, goclipper2.Paths64{lShape}, isClosed, goclipper2.NonZero)
+			assertSamePaths64(t, goclipper2.MinkowskiDiff64(pattern, lShape, isClosed), diff)
+		}
+	}
+}
+
+func TestMinkowskiDiffPathsD(t *testing.T) {
+	pattern := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 0.1, 0, 0.1, 0.1, 0, 0.1)}
+	paths := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 0, 1)}
+
+	// a ring round the outline, the pattern being narrower than the path
+	results := goclipper2.MinkowskiDiffPathsD(pattern, paths, true, goclipper2.NonZero)
+	assert.Equal(t, 2, len(results))
+	assert.InDelta(t, 1.1*1.1-0.9*0.9, goclipper2.AreaPathsD(results), 1e-9)
+	bounds := goclipper2.GetBounds64(goclipper2.PathDToPath64(goclipper2.ScalePathD(results[0], 10)))
+	assert.Equal(t, goclipper2.NewRect64(-1, -1, 10, 10), bounds)
+
+	polytree := goclipper2.MinkowskiDiffPolyTreeD(pattern, paths, true, goclipper2.NonZero)
+	assert.Equal(t, 1, len(polytree.GetChildren()))
+
+	sum := goclipper2.MinkowskiSumPathsD(pattern, paths, true, goclipper2.NonZero)
+	assert.InDelta(t, 1.1*1.1-0.9*0.9, goclipper2.AreaPathsD(sum), 1e-9)
+	assert.Equal(t, 1, len(goclipper2.MinkowskiSumPolyTreeD(pattern, paths, true, goclipper2.NonZero).GetChildren()))
+}
+
+func TestMinkowskiConvex64(t *testing.T) {
+	var (
+		square   = goclipper2.MakePath64(0, 0, 200, 0, 200, 200, 0, 200)
+		hexagon  = goclipper2.MakePath64(0, -50, 43, -25, 43, 25, 0, 50, -43, 25, -43, -25)
+		triangle = goclipper2.MakePath64(0, 0, 300, 0, 150, 260)
+		lShape   = goclipper2.MakePath64(0, 0, 200, 0, 200, 60, 60, 60, 60, 200, 0, 200)
+		circle   = goclipper2.Ellipse64(goclipper2.Point64{X: 10, Y: -20}, 30, 30, 0)
+		big      = goclipper2.Ellipse64(goclipper2.Point64{}, 400, 400, 64)
+	)
+
+	tests := []struct {
+		name     string
+		pattern  goclipper2.Path64
+		path     goclipper2.Path64
+		isClosed bool
+	}{
+		{name: "circle along square", pattern: circle, path: square, isClosed: true},
+		{name: "hexagon along triangle", pattern: hexagon, path: triangle, isClosed: true},
+		{name: "clockwise path", pattern: hexagon, path: goclipper2.ReversePath(square), isClosed: true},
+		{name: "pattern larger than path", pattern: big, path: triangle, isClosed: true},
+		{name: "concave path", pattern: circle, path: lShape, isClosed: true},
+		{name: "open path", pattern: hexagon, path: lShape},
+		{name: "open path inside pattern", pattern: big, path: goclipper2.MakePath64(0, 0, 100, 0)},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			for _, isSum := range []bool{true, false} {
+				var results goclipper2.Paths64
+				if isSum {
+					results = goclipper2.MinkowskiSum64(tt.pattern, tt.path, tt.isClosed)
+				} else {
+					results = goclipper2.MinkowskiDiff64(tt.pattern, tt.path, tt.isClosed)
+				}
+				expect := minkowskiQuads64(tt.pattern, tt.path, isSum, tt.isClosed)
+
+				// the vertices of holes are rounded independently by the two methods
+				area := goclipper2.AreaPaths64(expect)
+				assert.InEpsilon(t, area, goclipper2.AreaPaths64(results), 1e-3)
+				assert.Equal(t, len(expect), len(results))
+				xor := goclipper2.XorWithClipPaths64(results, expect, goclipper2.NonZero)
+				assert.Less(t, goclipper2.AreaPaths64(xor), area*1e-3)
+			}
+		})
+	}
+}
+
+// assertSamePaths64 checks that results cover the same region as expect,
+// whatever vertex each path starts at and wherever collinear vertices are.
+func assertSamePaths64(t *testing.T, expect, results goclipper2.Paths64) {
+	t.Helper()
+	assert.Equal(t, len(expect), len(results))
+	assert.InDelta(t, goclipper2.AreaPaths64(expect), goclipper2.AreaPaths64(results), 1e-9)
+	assert.Equal(t, 0, len(goclipper2.XorWithClipPaths64(results, expect, goclipper2.NonZero)))
+}
+
+func assertSamePathsD(t *testing.T, expect, results goclipper2.PathsD) {
+	t.Helper()
+	assert.Equal(t, len(expect), len(results))
+	assert.InDelta(t, goclipper2.AreaPathsD(expect), goclipper2.AreaPathsD(results), 1e-9)
+	assert.Equal(t, 0, len(goclipper2.XorWithClipPathsD(results, expect, goclipper2.NonZero)))
+}
+
+// minkowskiQuads64 sweeps pattern along path one edge pair at a time.
+func minkowskiQuads64(pattern, path goclipper2.Path64, isSum, isClosed bool) goclipper2.Paths64 {
+	at := func(i, j int) goclipper2.Point64 {
+		if isSum {
+			return goclipper2.Point64{X: path[i].X + pattern[j].X, Y: path[i].Y + pattern[j].Y}
+		}
+		return goclipper2.Point64{X: path[i].X - pattern[j].X, Y: path[i].Y - pattern[j].Y}
+	}
+
+	quads := goclipper2.Paths64{}
+	for i := range path {
+		g := i - 1
+		if i == 0 {
+			if !isClosed {
+				continue
+			}
+			g = len(path) - 1
+		}
+		for j := range pattern {
+			h := (j + len(pattern) - 1) % len(pattern)
+			quad := goclipper2.Path64{at(g, h), at(i, h), at(i, j), at(g, j)}
+			if !goclipper2.IsPositive64(quad) {
+				quad = goclipper2.ReversePath(quad)
+			}
+			quads = append(quads, quad)
+		}
+	}
+	return goclipper2.UnionPaths64(quads, goclipper2.NonZero)
+}
+
+func BenchmarkMinkowskiSum64(b *testing.B) {
+	// the rounded vertices of a finely stepped ellipse make it slightly concave
+	path := goclipper2.Ellipse64(goclipper2.Point64{X: 500, Y: 500}, 400, 300, 256)
+	convexPath := goclipper2.Ellipse64(goclipper2.Point64{X: 500, Y: 500}, 400, 300, 0)
+	convex := goclipper2.Ellipse64(goclipper2.Point64{}, 20, 20, 0)
+	// the same circle with a notch, which forces the quad based method
+	concave := append(goclipper2.Path64{{X: 5, Y: 0}}, convex[1:]...)
+
+	b.Run("convex pattern and path", func(b *testing.B) {
+		for b.Loop() {
+			_ = goclipper2.MinkowskiSum64(convex, convexPath, true)
+		}
+	})
+
+	b.Run("convex pattern", func(b *testing.B) {
+		for b.Loop() {
+			_ = goclipper2.MinkowskiSum64(convex, path, true)
+		}
+	})
+
+	b.Run("concave pattern", func(b *testing.B) {
+		for b.Loop() {
+			_ = goclipper2.MinkowskiSum64(concave, path, true)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package go_clipper2
+
+import (
+	"math"
+	"slices"
+)
+
+// Open64 shrinks the closed paths by delta and grows what's left back by
+// delta, removing the spikes, slivers and islands narrower than 2·delta. The
+// regrowth also applies joinType to the convex corners: Round rounds them to
+// radius delta and Square and Bevel cut them off, so only Miter with a miter
+// limit large enough for the sharpest corner leaves the rest as it was. Both
+// offsets share joinType and opts (the miter limit and arc tolerance), and the
+// result is unioned.
+func Open64(paths Paths64, delta float64, joinType JoinType, opts ...InflateOption) Paths64 {
+	return morphology64(paths, -math.Abs(delta), joinType, opts...)
+}
+
+// Close64 is the reverse of Open64: it grows the closed paths by delta and
+// shrinks them back, filling the gaps, notches and holes narrower than
+// 2·delta and joining paths less than 2·delta apart. Likewise, unless joinType
+// is Miter with a large enough limit, the concave corners come back rounded or
+// filled in.
+func Close64(paths Paths64, delta float64, joinType JoinType, opts ...InflateOption) Paths64 {
+	return morphology64(paths, math.Abs(delta), joinType, opts...)
+}
+
+// Smooth64 opens and then closes the paths, so features narrower than
+// 2·delta go whether they stick out or cut in.
+func Smooth64(paths Paths64, delta float64, joinType JoinType, opts ...InflateOption) Paths64 {
+	return Close64(Open64(paths, delta, joinType, opts...), delta, joinType, opts...)
+}
+
+func OpenD(paths PathsD, delta float64, joinType JoinType, opts ...InflateOption) PathsD {
+	return morphologyD(paths, -math.Abs(delta), joinType, opts...)
+}
+
+func CloseD(paths PathsD, delta float64, joinType JoinType, opts ...InflateOption) PathsD {
+	return morphologyD(paths, math.Abs(delta), joinType, opts...)
+}
+
+func SmoothD(paths PathsD, delta float64, joinType JoinType, opts ...InflateOption) PathsD {
+	return CloseD(OpenD(paths, delta, joinType, opts...), delta, joinType, opts...)
+}
+
+// morphology64 offsets paths by delta and the result by -delta.
+func morphology64(paths Paths64, delta float64, joinType JoinType, opts ...InflateOption) Paths64 {
+	cfg := &inflateConfig{
+		miterLimit:   2.0,
+		arcTolerance: 0,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	solution := paths
+	for _, d := range []float64{delta, -delta} {
+		co := NewClipperOffset(cfg.miterLimit, cfg.arcTolerance, false, false)
+		co.AddPaths(solution, joinType, Polygon)
+		solution = make(Paths64, 0)
+		co.Execute64(d, &solution)
+	}
+	return UnionPaths64(solution, NonZero)
+}
+
+func morphologyD(paths PathsD, delta float64, joinType JoinType, opts ...InflateOption) PathsD {
+	cfg := &inflateConfig{
+		arcTolerance: 0,
+		precision:    2,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// panic if wrong precision
+	checkPrecision(cfg.precision)
+
+	scale := math.Pow(10, float64(cfg.precision))
+	// a copy, so the caller's opts aren't written to through spare capacity
+	opts = append(slices.Clone(opts), WithArcTolerance(cfg.arcTolerance*scale))
+	tmp := morphology64(ScalePathsDToPaths64(paths, scale), delta*scale, joinType, opts...)
+
+	return ScalePaths64ToPathsD(tmp, 1/scale)
+}
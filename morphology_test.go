@@ -0,0 +1,71 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenClose64(t *testing.T) {
+	// a square with a 2 wide spike out of its right side
+	spiked := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 49, 150, 49, 150, 51, 100, 51, 100, 100, 0, 100)}
+	// a square with a 2 wide slot cut into its right side
+	slotted := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 49, 50, 49, 50, 51, 100, 51, 100, 100, 0, 100)}
+	// two squares 4 apart
+	pair := goclipper2.Paths64{
+		goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+		goclipper2.MakePath64(104, 0, 204, 0, 204, 100, 104, 100),
+	}
+	// a square and a speck
+	specked := goclipper2.Paths64{
+		goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+		goclipper2.MakePath64(200, 0, 206, 0, 206, 6, 200, 6),
+	}
+
+	tests := []struct {
+		name  string
+		op    func(goclipper2.Paths64, float64, goclipper2.JoinType, ...goclipper2.InflateOption) goclipper2.Paths64
+		paths goclipper2.Paths64
+		cnt   int
+		area  float64
+	}{
+		{name: "open removes a spike", op: goclipper2.Open64, paths: spiked, cnt: 1, area: 100 * 100},
+		{name: "open keeps a slot", op: goclipper2.Open64, paths: slotted, cnt: 1, area: 100*100 - 50*2},
+		{name: "open removes a speck", op: goclipper2.Open64, paths: specked, cnt: 1, area: 100 * 100},
+		{name: "open keeps a gap", op: goclipper2.Open64, paths: pair, cnt: 2, area: 2 * 100 * 100},
+		{name: "close fills a slot", op: goclipper2.Close64, paths: slotted, cnt: 1, area: 100 * 100},
+		{name: "close keeps a spike", op: goclipper2.Close64, paths: spiked, cnt: 1, area: 100*100 + 50*2},
+		{name: "close bridges a gap", op: goclipper2.Close64, paths: pair, cnt: 1, area: 204 * 100},
+		{name: "smooth removes a spike", op: goclipper2.Smooth64, paths: spiked, cnt: 1, area: 100 * 100},
+		{name: "smooth fills a slot", op: goclipper2.Smooth64, paths: slotted, cnt: 1, area: 100 * 100},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			result := tt.op(tt.paths, 5, goclipper2.Miter)
+			assert.Equal(t, tt.cnt, len(result))
+			assert.InDelta(t, tt.area, goclipper2.AreaPaths64(result), 1)
+		})
+	}
+
+	// with round joins the square's corners get rounded off by opening only
+	opened := goclipper2.Open64(goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}, 10, goclipper2.Round)
+	closed := goclipper2.Close64(goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}, 10, goclipper2.Round)
+	assert.InDelta(t, 100*100-(4-3.1416)*10*10, goclipper2.AreaPaths64(opened), 20)
+	assert.InDelta(t, 100*100, goclipper2.AreaPaths64(closed), 5)
+}
+
+func TestOpenCloseD(t *testing.T) {
+	spiked := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 0.49, 1.5, 0.49, 1.5, 0.51, 1, 0.51, 1, 1, 0, 1)}
+	assert.InDelta(t, 1, goclipper2.AreaPathsD(goclipper2.OpenD(spiked, 0.05, goclipper2.Miter, goclipper2.WithPrecision(3))), 1e-3)
+	assert.InDelta(t, 1.01, goclipper2.AreaPathsD(goclipper2.CloseD(spiked, 0.05, goclipper2.Miter, goclipper2.WithPrecision(3))), 1e-3)
+	assert.InDelta(t, 1, goclipper2.AreaPathsD(goclipper2.SmoothD(spiked, 0.05, goclipper2.Miter, goclipper2.WithPrecision(3))), 1e-3)
+
+	// the caller's options aren't written to through their spare capacity
+	opts := make([]goclipper2.InflateOption, 1, 2)
+	opts[0] = goclipper2.WithPrecision(3)
+	goclipper2.OpenD(spiked, 0.05, goclipper2.Round, opts...)
+	assert.Nil(t, opts[:2][1])
+}
@@ -0,0 +1,228 @@
+package go_clipper2
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// NestPart64 is a part to be placed by Nest64. Rotations lists the allowed
+// rotations in radians, counter-clockwise about the origin, in order of
+// preference. An empty list only allows the part unrotated.
+type NestPart64 struct {
+	Paths     Paths64
+	Rotations []float64
+}
+
+type NestPartD struct {
+	Paths     PathsD
+	Rotations []float64
+}
+
+// Placement64 places parts[Part] by rotating it about the origin by Rotation
+// and then moving it by Translation.
+type Placement64 struct {
+	Part        int
+	Rotation    float64
+	Translation Point64
+}
+
+type PlacementD struct {
+	Part        int
+	Rotation    float64
+	Translation PointD
+}
+
+// Transform returns paths rotated and translated as p describes.
+func (p Placement64) Transform(paths Paths64) Paths64 {
+	return TranslatePaths64(rotatePaths64(paths, p.Rotation), p.Translation.X, p.Translation.Y)
+}
+
+func (p PlacementD) Transform(paths PathsD) PathsD {
+	cosA, sinA := math.Cos(p.Rotation), math.Sin(p.Rotation)
+	result := make(PathsD, 0, len(paths))
+	for _, path := range paths {
+		moved := make(PathD, len(path))
+		for i, pt := range path {
+			x, y := rotatePoint(pt.X, pt.Y, cosA, sinA)
+			moved[i] = PointD{X: x + p.Translation.X, Y: y + p.Translation.Y}
+		}
+		result = append(result, moved)
+	}
+	return result
+}
+
+// Nest64 places parts inside container without overlap using bottom-left
+// fill. Parts are placed largest first; each one goes to the position with the
+// smallest Y, then the smallest X, over all its rotations, at which it lies
+// inside container and doesn't overlap the parts already placed. Parts may
+// touch each other and the container. Parts that can't be placed are returned
+// by index in unplaced. Both container and parts are interpreted by fillRule.
+func Nest64(container Paths64, parts []NestPart64, fillRule FillRule) (placements []Placement64, unplaced []int) {
+	container = UnionPaths64(container, fillRule)
+
+	shapes := make([]Paths64, len(parts))
+	order := make([]int, len(parts))
+	for i, part := range parts {
+		shapes[i] = UnionPaths64(part.Paths, fillRule)
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(AreaPaths64(shapes[b]), AreaPaths64(shapes[a]))
+	})
+
+	placements = make([]Placement64, 0, len(parts))
+	unplaced = make([]int, 0)
+	placed := make(Paths64, 0)
+	for _, idx := range order {
+		if len(shapes[idx]) == 0 {
+			unplaced = append(unplaced, idx)
+			continue
+		}
+
+		rotations := parts[idx].Rotations
+		if len(rotations) == 0 {
+			rotations = []float64{0}
+		}
+
+		found := false
+		var best Placement64
+		var bestShape Paths64
+		for _, rotation := range rotations {
+			shape := rotatePaths64(shapes[idx], rotation)
+			pt, ok := bottomLeftPosition64(container, placed, shape)
+			if !ok {
+				continue
+			}
+			if !found || pt.Y < best.Translation.Y || (pt.Y == best.Translation.Y && pt.X < best.Translation.X) {
+				found = true
+				best = Placement64{Part: idx, Rotation: rotation, Translation: pt}
+				bestShape = shape
+			}
+		}
+
+		if !found {
+			unplaced = append(unplaced, idx)
+			continue
+		}
+		placements = append(placements, best)
+		placed = append(placed, TranslatePaths64(bestShape, best.Translation.X, best.Translation.Y)...)
+	}
+
+	return placements, unplaced
+}
+
+// NestD is Nest64 for PathsD, with coordinates rounded to precisionV decimal
+// places (2 by default) while nesting.
+func NestD(container PathsD, parts []NestPartD, fillRule FillRule, precisionV ...int) (placements []PlacementD, unplaced []int) {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+	checkPrecision(precision)
+	scale := math.Pow(10, float64(precision))
+
+	sParts := make([]NestPart64, len(parts))
+	for i, part := range parts {
+		sParts[i] = NestPart64{Paths: ScalePathsDToPaths64(part.Paths, scale), Rotations: part.Rotations}
+	}
+
+	sPlacements, unplaced := Nest64(ScalePathsDToPaths64(container, scale), sParts, fillRule)
+	placements = make([]PlacementD, len(sPlacements))
+	for i, p := range sPlacements {
+		placements[i] = PlacementD{
+			Part:        p.Part,
+			Rotation:    p.Rotation,
+			Translation: PointD{X: float64(p.Translation.X) / scale, Y: float64(p.Translation.Y) / scale},
+		}
+	}
+	return placements, unplaced
+}
+
+// bottomLeftPosition64 returns the lowest, then leftmost, translation at which
+// shape lies inside container without overlapping obstacles. The feasible
+// translations are the inner-fit polygon less the no-fit polygon, but where
+// parts fit exactly these have no area, so the candidates are the vertices of
+// both polygons and of their difference, and the points where the inner-fit
+// polygon's boundary leaves the no-fit polygon. As these may be rounded each
+// candidate is checked before it's accepted.
+func bottomLeftPosition64(container, obstacles, shape Paths64) (Point64, bool) {
+	bounds := ifpCandidates64(container, shape)
+	if bounds.right < bounds.left || bounds.bottom < bounds.top {
+		return Point64{}, false
+	}
+
+	ifp := ifpPaths64(container, shape, NonZero)
+	if len(ifp) == 0 {
+		ifp = Paths64{bounds.AsPath()}
+	}
+	var nfp Paths64
+	if len(obstacles) > 0 {
+		nfp = nfpPaths64(obstacles, shape, NonZero)
+	}
+
+	candidates := make(Path64, 0)
+	for _, paths := range []Paths64{ifp, nfp, DifferenceWithClipPaths64(ifp, nfp, NonZero)} {
+		for _, path := range paths {
+			candidates = append(candidates, path...)
+		}
+	}
+	if len(nfp) > 0 {
+		boundary := make(Paths64, 0, len(ifp))
+		for _, path := range ifp {
+			boundary = append(boundary, append(slices.Clone(path), path[0]))
+		}
+		c := NewClipper64()
+		c.AddPaths(boundary, Subject, true)
+		c.AddPaths(nfp, Clip, false)
+		closed, open := Paths64{}, Paths64{}
+		c.ExecuteOC(Difference, NonZero, &closed, &open)
+		for _, path := range open {
+			candidates = append(candidates, path[0], path[len(path)-1])
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b Point64) int {
+		if c := cmp.Compare(a.Y, b.Y); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.X, b.X)
+	})
+	candidates = slices.Compact(candidates)
+
+	for _, pt := range candidates {
+		if pt.X < bounds.left || pt.X > bounds.right || pt.Y < bounds.top || pt.Y > bounds.bottom {
+			continue
+		}
+		if len(nfp) > 0 && PointInPaths64(pt, nfp, NonZero) == IsInside {
+			continue
+		}
+
+		moved := TranslatePaths64(shape, pt.X, pt.Y)
+		if AreaPaths64(DifferenceWithClipPaths64(moved, container, NonZero)) != 0 {
+			continue
+		}
+		if len(obstacles) > 0 && AreaPaths64(IntersectWithClipPaths64(moved, obstacles, NonZero)) != 0 {
+			continue
+		}
+		return pt, true
+	}
+	return Point64{}, false
+}
+
+func rotatePaths64(paths Paths64, angle float64) Paths64 {
+	if angle == 0 {
+		return paths
+	}
+
+	cosA, sinA := math.Cos(angle), math.Sin(angle)
+	result := make(Paths64, 0, len(paths))
+	for _, path := range paths {
+		rotated := make(Path64, len(path))
+		for i, pt := range path {
+			rotated[i] = NewFloatPoint64(rotatePoint(float64(pt.X), float64(pt.Y), cosA, sinA))
+		}
+		result = append(result, rotated)
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNest64(t *testing.T) {
+	var (
+		sheet  = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}
+		square = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 50, 0, 50, 50, 0, 50)}
+		tall   = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 20, 0, 20, 80, 0, 80)}
+		lShape = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 40, 0, 40, 15, 15, 15, 15, 40, 0, 40)}
+		strip  = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 30, 0, 30)}
+	)
+
+	tests := []struct {
+		name      string
+		container goclipper2.Paths64
+		parts     []goclipper2.NestPart64
+		expect    []goclipper2.Point64
+		unplaced  []int
+	}{
+		{
+			name:      "exact tiling",
+			container: sheet,
+			parts: []goclipper2.NestPart64{
+				{Paths: square}, {Paths: square}, {Paths: square}, {Paths: square},
+			},
+			expect:   []goclipper2.Point64{{X: 0, Y: 0}, {X: 50, Y: 0}, {X: 0, Y: 50}, {X: 50, Y: 50}},
+			unplaced: []int{},
+		},
+		{
+			name:      "too many parts",
+			container: sheet,
+			parts: []goclipper2.NestPart64{
+				{Paths: square}, {Paths: square}, {Paths: square}, {Paths: square}, {Paths: square},
+			},
+			expect:   []goclipper2.Point64{{X: 0, Y: 0}, {X: 50, Y: 0}, {X: 0, Y: 50}, {X: 50, Y: 50}},
+			unplaced: []int{4},
+		},
+		{
+			name:      "needs rotation",
+			container: strip,
+			parts: []goclipper2.NestPart64{
+				{Paths: tall},
+				{Paths: tall, Rotations: []float64{0, math.Pi / 2}},
+			},
+			expect:   []goclipper2.Point64{{X: 80, Y: 0}},
+			unplaced: []int{0},
+		},
+		{
+			name:      "largest first",
+			container: sheet,
+			parts: []goclipper2.NestPart64{
+				{Paths: lShape, Rotations: []float64{0, math.Pi / 2, math.Pi, 3 * math.Pi / 2}},
+				{Paths: square},
+				{Paths: lShape, Rotations: []float64{0, math.Pi / 2, math.Pi, 3 * math.Pi / 2}},
+			},
+			unplaced: []int{},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			placements, unplaced := goclipper2.Nest64(tt.container, tt.parts, goclipper2.NonZero)
+			assert.Equal(t, tt.unplaced, unplaced)
+			if tt.expect != nil {
+				translations := make([]goclipper2.Point64, len(placements))
+				for j, p := range placements {
+					translations[j] = p.Translation
+				}
+				assert.Equal(t, tt.expect, translations)
+			}
+
+			placed := goclipper2.Paths64{}
+			for _, p := range placements {
+				moved := p.Transform(tt.parts[p.Part].Paths)
+				outside := goclipper2.DifferenceWithClipPaths64(moved, tt.container, goclipper2.NonZero)
+				assert.Equal(t, 0.0, goclipper2.AreaPaths64(outside), "part %d outside", p.Part)
+				overlap := goclipper2.IntersectWithClipPaths64(moved, placed, goclipper2.NonZero)
+				assert.Equal(t, 0.0, goclipper2.AreaPaths64(overlap), "part %d overlaps", p.Part)
+				placed = append(placed, moved...)
+			}
+		})
+	}
+}
+
+func TestNestD(t *testing.T) {
+	sheet := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 0.5, 0, 0.5)}
+	part := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 0.5, 0, 0.5, 0.5, 0, 0.5)}
+
+	placements, unplaced := goclipper2.NestD(sheet, []goclipper2.NestPartD{{Paths: part}, {Paths: part}, {Paths: part}}, goclipper2.NonZero)
+	assert.Equal(t, []int{2}, unplaced)
+	assert.Equal(t, 2, len(placements))
+	assert.Equal(t, goclipper2.PointD{X: 0, Y: 0}, placements[0].Translation)
+	assert.Equal(t, goclipper2.PointD{X: 0.5, Y: 0}, placements[1].Translation)
+	assert.Equal(t, goclipper2.MakePathD(0.5, 0, 1, 0, 1, 0.5, 0.5, 0.5), placements[1].Transform(part)[0])
+}
@@ -0,0 +1,85 @@
+package go_clipper2
+
+import (
+	"math"
+)
+
+// NoFitPolygon64 returns the no-fit polygon of orbiting around stationary:
+// the set of translations of orbiting at which the two polygon sets overlap.
+// Translations on the boundary make the parts touch, translations inside holes
+// of the result place orbiting inside a hole of stationary without overlap.
+func NoFitPolygon64(stationary, orbiting Paths64, fillRule FillRule) *PolyTree64 {
+	return BooleanOpPolyTree64(Union, nfpPaths64(stationary, orbiting, fillRule), nil, NonZero)
+}
+
+func NoFitPolygonD(stationary, orbiting PathsD, fillRule FillRule, precisionV ...int) *PolyTreeD {
+	c, scale := newMinkowskiClipperD(precisionV...)
+	tmp := nfpPaths64(ScalePathsDToPaths64(stationary, scale), ScalePathsDToPaths64(orbiting, scale), fillRule)
+	return c.polyTree(tmp)
+}
+
+// InnerFitPolygon64 returns the set of translations of part that keep it
+// entirely inside container (touching the boundary is allowed).
+func InnerFitPolygon64(container, part Paths64, fillRule FillRule) *PolyTree64 {
+	return BooleanOpPolyTree64(Union, ifpPaths64(container, part, fillRule), nil, NonZero)
+}
+
+func InnerFitPolygonD(container, part PathsD, fillRule FillRule, precisionV ...int) *PolyTreeD {
+	c, scale := newMinkowskiClipperD(precisionV...)
+	tmp := ifpPaths64(ScalePathsDToPaths64(container, scale), ScalePathsDToPaths64(part, scale), fillRule)
+	return c.polyTree(tmp)
+}
+
+func nfpPaths64(stationary, orbiting Paths64, fillRule FillRule) Paths64 {
+	return UnionPaths64(minkowskiPathsInternal(orbiting, stationary, false, fillRule), NonZero)
+}
+
+func ifpPaths64(container, part Paths64, fillRule FillRule) Paths64 {
+	container = UnionPaths64(container, fillRule)
+	part = UnionPaths64(part, fillRule)
+	if len(container) == 0 || len(part) == 0 {
+		return Paths64{}
+	}
+
+	cb := pathsBounds64(container)
+	pb := pathsBounds64(part)
+	w := pb.right - pb.left + 1
+	h := pb.bottom - pb.top + 1
+
+	// every translation that could fit lies inside candidates, and the part
+	// placed at any such translation lies inside frame
+	candidates := ifpCandidates64(container, part)
+	if candidates.IsEmpty() {
+		return Paths64{}
+	}
+	frame := NewRect64(cb.left-w, cb.top-h, cb.right+w, cb.bottom+h)
+
+	outside := DifferenceWithClipPaths64(Paths64{frame.AsPath()}, container, NonZero)
+	blocked := nfpPaths64(outside, part, NonZero)
+	return DifferenceWithClipPaths64(Paths64{candidates.AsPath()}, blocked, NonZero)
+}
+
+// ifpCandidates64 returns the translations that keep the bounds of part inside
+// the bounds of container. It's inverted if there are none and has no area if
+// part only fits exactly.
+func ifpCandidates64(container, part Paths64) Rect64 {
+	cb := pathsBounds64(container)
+	pb := pathsBounds64(part)
+	return NewRect64(cb.left-pb.left, cb.top-pb.top, cb.right-pb.right, cb.bottom-pb.bottom)
+}
+
+func pathsBounds64(paths Paths64) Rect64 {
+	result := NewRect64Invalid(false)
+	for _, path := range paths {
+		for _, pt := range path {
+			result.left = min(result.left, pt.X)
+			result.top = min(result.top, pt.Y)
+			result.right = max(result.right, pt.X)
+			result.bottom = max(result.bottom, pt.Y)
+		}
+	}
+	if result.left == math.MaxInt64 {
+		return Rect64{}
+	}
+	return result
+}
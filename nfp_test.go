@@ -0,0 +1,123 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoFitPolygon64(t *testing.T) {
+	var (
+		square   = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}
+		triangle = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 30, 0, 0, 30)}
+		lShape   = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 30, 30, 30, 30, 100, 0, 100)}
+		frame    = goclipper2.Paths64{
+			goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+			goclipper2.MakePath64(20, 20, 20, 80, 80, 80, 80, 20),
+		}
+		small = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 20, 0, 20, 20, 0, 20)}
+	)
+
+	tests := []struct {
+		name       string
+		stationary goclipper2.Paths64
+		orbiting   goclipper2.Paths64
+		area       float64
+	}{
+		{
+			name:       "convex squares",
+			stationary: square,
+			orbiting:   small,
+			area:       120 * 120,
+		},
+		{
+			name:       "convex square and triangle",
+			stationary: square,
+			orbiting:   triangle,
+			area:       130*130 - 30*30/2,
+		},
+		{
+			name:       "concave l-shape",
+			stationary: lShape,
+			orbiting:   small,
+		},
+		{
+			name:       "stationary with hole",
+			stationary: frame,
+			orbiting:   small,
+			area:       120*120 - 40*40,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			nfp := goclipper2.PolyTreeToPaths64(goclipper2.NoFitPolygon64(tt.stationary, tt.orbiting, goclipper2.NonZero))
+			if tt.area != 0 {
+				assert.InDelta(t, tt.area, goclipper2.AreaPaths64(nfp), 1e-9)
+			}
+
+			// every sampled translation inside the nfp overlaps, outside it doesn't
+			for x := int64(-45); x <= 145; x += 10 {
+				for y := int64(-45); y <= 145; y += 10 {
+					moved := goclipper2.TranslatePaths64(tt.orbiting, x, y)
+					overlap := goclipper2.AreaPaths64(goclipper2.IntersectWithClipPaths64(tt.stationary, moved, goclipper2.NonZero)) > 0
+					assert.Equal(t, overlap, insidePaths64(goclipper2.Point64{X: x, Y: y}, nfp), "translation %d,%d", x, y)
+				}
+			}
+		})
+	}
+}
+
+func TestInnerFitPolygon64(t *testing.T) {
+	var (
+		container = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 60, 100, 60, 40, 40, 40, 40, 100, 0, 100)}
+		part      = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 20, 0, 20, 20, 0, 20)}
+	)
+
+	ifp := goclipper2.PolyTreeToPaths64(goclipper2.InnerFitPolygon64(container, part, goclipper2.NonZero))
+	assert.Greater(t, goclipper2.AreaPaths64(ifp), 0.0)
+
+	for x := int64(-5); x <= 105; x += 10 {
+		for y := int64(-5); y <= 105; y += 10 {
+			moved := goclipper2.TranslatePaths64(part, x, y)
+			fits := goclipper2.AreaPaths64(goclipper2.DifferenceWithClipPaths64(moved, container, goclipper2.NonZero)) == 0
+			assert.Equal(t, fits, insidePaths64(goclipper2.Point64{X: x, Y: y}, ifp), "translation %d,%d", x, y)
+		}
+	}
+
+	square := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}
+	ifp = goclipper2.PolyTreeToPaths64(goclipper2.InnerFitPolygon64(square, part, goclipper2.NonZero))
+	assert.InDelta(t, 80*80, goclipper2.AreaPaths64(ifp), 1e-9)
+
+	tooBig := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 200, 0, 200, 20, 0, 20)}
+	assert.Equal(t, 0, len(goclipper2.InnerFitPolygon64(square, tooBig, goclipper2.NonZero).GetChildren()))
+}
+
+func TestNoFitPolygonD(t *testing.T) {
+	square := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 0, 1)}
+	small := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 0.5, 0, 0.5, 0.5, 0, 0.5)}
+
+	nfp := goclipper2.NoFitPolygonD(square, small, goclipper2.NonZero)
+	assert.Equal(t, 1, len(nfp.GetChildren()))
+	assert.InDelta(t, 1.5*1.5*100*100, goclipper2.Area64(nfp.GetChildren()[0].Polygon()), 1e-9)
+
+	ifp := goclipper2.InnerFitPolygonD(square, small, goclipper2.NonZero)
+	assert.Equal(t, 1, len(ifp.GetChildren()))
+	assert.InDelta(t, 0.5*0.5*100*100, goclipper2.Area64(ifp.GetChildren()[0].Polygon()), 1e-9)
+}
+
+// insidePaths64 reports whether pt lies strictly inside paths (even-odd).
+func insidePaths64(pt goclipper2.Point64, paths goclipper2.Paths64) bool {
+	inside := false
+	for _, path := range paths {
+		switch goclipper2.PointInPolygon(pt, path) {
+		case goclipper2.IsOn:
+			return false
+		case goclipper2.IsInside:
+			inside = !inside
+		}
+	}
+	return inside
+}
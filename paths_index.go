@@ -0,0 +1,315 @@
+package go_clipper2
+
+import (
+	"cmp"
+	"container/heap"
+	"math"
+	"slices"
+)
+
+const pathsIndexNodeSize = 16
+
+// PathsIndex64 is a bounding box R-tree over the paths of a Paths64, used to
+// find the few paths near a rectangle or point without testing all of them,
+// e.g. to restrict RectClipPaths64 or a boolean operation to candidates. The
+// tree is bulk loaded (sort-tile-recursive); paths inserted later are kept
+// aside and the tree is rebuilt once enough of them have accumulated.
+type PathsIndex64 struct {
+	paths   Paths64
+	bounds  []Rect64
+	nodes   []pathsIndexNode
+	root    int
+	pending []int
+}
+
+type pathsIndexNode struct {
+	bounds  Rect64
+	entries []int // child nodes, or paths in a leaf
+	leaf    bool
+}
+
+func NewPathsIndex64(paths Paths64) *PathsIndex64 {
+	idx := &PathsIndex64{
+		paths:  make(Paths64, 0, len(paths)),
+		bounds: make([]Rect64, 0, len(paths)),
+		root:   -1,
+	}
+	for _, path := range paths {
+		idx.paths = append(idx.paths, path)
+		idx.bounds = append(idx.bounds, GetBounds64(path))
+	}
+	idx.build()
+	return idx
+}
+
+// Count returns the number of indexed paths.
+func (idx *PathsIndex64) Count() int {
+	return len(idx.paths)
+}
+
+// Path returns the path at index i, as passed to NewPathsIndex64 or Insert.
+func (idx *PathsIndex64) Path(i int) Path64 {
+	return idx.paths[i]
+}
+
+// Insert adds path to the index and returns its index.
+func (idx *PathsIndex64) Insert(path Path64) int {
+	i := len(idx.paths)
+	idx.paths = append(idx.paths, path)
+	idx.bounds = append(idx.bounds, GetBounds64(path))
+	idx.pending = append(idx.pending, i)
+	if len(idx.pending) > max(pathsIndexNodeSize, len(idx.paths)/8) {
+		idx.build()
+	}
+	return i
+}
+
+// Query returns, in ascending order, the indices of the paths whose bounds
+// intersect rect (touching counts).
+func (idx *PathsIndex64) Query(rect Rect64) []int {
+	result := make([]int, 0)
+	if idx.root >= 0 {
+		stack := []int{idx.root}
+		for len(stack) > 0 {
+			node := &idx.nodes[stack[len(stack)-1]]
+			stack = stack[:len(stack)-1]
+			for _, e := range node.entries {
+				if node.leaf {
+					if idx.bounds[e].Intersects(rect) {
+						result = append(result, e)
+					}
+				} else if idx.nodes[e].bounds.Intersects(rect) {
+					stack = append(stack, e)
+				}
+			}
+		}
+	}
+	for _, i := range idx.pending {
+		if idx.bounds[i].Intersects(rect) {
+			result = append(result, i)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// QueryPaths returns the paths Query finds for rect.
+func (idx *PathsIndex64) QueryPaths(rect Rect64) Paths64 {
+	found := idx.Query(rect)
+	result := make(Paths64, len(found))
+	for i, j := range found {
+		result[i] = idx.paths[j]
+	}
+	return result
+}
+
+// Nearest returns the index of the closed path nearest to pt and its distance,
+// which is 0 if pt is inside or on the path. It returns -1 if the index is
+// empty.
+func (idx *PathsIndex64) Nearest(pt Point64) (int, float64) {
+	best, bestDist := -1, math.Inf(1)
+	visit := func(i int) {
+		if d := pathDistanceSqr64(pt, idx.paths[i]); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	for _, i := range idx.pending {
+		visit(i)
+	}
+
+	if idx.root >= 0 {
+		// best first: nodes and paths are visited in order of the distance to
+		// their bounds, which never exceeds the distance to what's inside
+		queue := &pathsIndexQueue{{dist: rectDistanceSqr64(pt, idx.nodes[idx.root].bounds), node: idx.root}}
+		for queue.Len() > 0 {
+			item := heap.Pop(queue).(pathsIndexItem)
+			if item.dist >= bestDist {
+				break
+			}
+			if item.node < 0 {
+				visit(item.path)
+				continue
+			}
+			node := &idx.nodes[item.node]
+			for _, e := range node.entries {
+				if node.leaf {
+					heap.Push(queue, pathsIndexItem{dist: rectDistanceSqr64(pt, idx.bounds[e]), node: -1, path: e})
+				} else {
+					heap.Push(queue, pathsIndexItem{dist: rectDistanceSqr64(pt, idx.nodes[e].bounds), node: e})
+				}
+			}
+		}
+	}
+
+	if best < 0 {
+		return -1, 0
+	}
+	return best, math.Sqrt(bestDist)
+}
+
+// build packs every path into a new tree, one level at a time.
+func (idx *PathsIndex64) build() {
+	idx.nodes = idx.nodes[:0]
+	idx.pending = idx.pending[:0]
+	idx.root = -1
+
+	entries := make([]int, 0, len(idx.paths))
+	for i, path := range idx.paths {
+		if len(path) > 0 {
+			entries = append(entries, i)
+		}
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	entries = idx.pack(entries, func(i int) Rect64 { return idx.bounds[i] }, true)
+	for len(entries) > 1 {
+		entries = idx.pack(entries, func(i int) Rect64 { return idx.nodes[i].bounds }, false)
+	}
+	idx.root = entries[0]
+}
+
+// pack groups entries into nodes of up to pathsIndexNodeSize: sorted by X
+// into vertical slices, then by Y within each slice. It returns the new nodes.
+func (idx *PathsIndex64) pack(entries []int, boundsOf func(int) Rect64, leaf bool) []int {
+	midX := func(i int) int64 { b := boundsOf(i); return b.left/2 + b.right/2 }
+	midY := func(i int) int64 { b := boundsOf(i); return b.top/2 + b.bottom/2 }
+
+	nodeCnt := (len(entries) + pathsIndexNodeSize - 1) / pathsIndexNodeSize
+	sliceCnt := int(math.Ceil(math.Sqrt(float64(nodeCnt))))
+	sliceSize := sliceCnt * pathsIndexNodeSize
+
+	slices.SortFunc(entries, func(a, b int) int { return cmp.Compare(midX(a), midX(b)) })
+	result := make([]int, 0, nodeCnt)
+	for start := 0; start < len(entries); start += sliceSize {
+		slice := entries[start:min(start+sliceSize, len(entries))]
+		slices.SortFunc(slice, func(a, b int) int { return cmp.Compare(midY(a), midY(b)) })
+		for i := 0; i < len(slice); i += pathsIndexNodeSize {
+			node := pathsIndexNode{
+				bounds:  NewRect64Invalid(false),
+				entries: slices.Clone(slice[i:min(i+pathsIndexNodeSize, len(slice))]),
+				leaf:    leaf,
+			}
+			for _, e := range node.entries {
+				b := boundsOf(e)
+				node.bounds.left, node.bounds.top = min(node.bounds.left, b.left), min(node.bounds.top, b.top)
+				node.bounds.right, node.bounds.bottom = max(node.bounds.right, b.right), max(node.bounds.bottom, b.bottom)
+			}
+			idx.nodes = append(idx.nodes, node)
+			result = append(result, len(idx.nodes)-1)
+		}
+	}
+	return result
+}
+
+type pathsIndexItem struct {
+	dist float64
+	node int // -1 for a path
+	path int
+}
+
+type pathsIndexQueue []pathsIndexItem
+
+func (q pathsIndexQueue) Len() int           { return len(q) }
+func (q pathsIndexQueue) Less(i, j int) bool { return q[i].dist < q[j].dist }
+func (q pathsIndexQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *pathsIndexQueue) Push(x any)        { *q = append(*q, x.(pathsIndexItem)) }
+func (q *pathsIndexQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// PathsIndexD is PathsIndex64 for PathsD, with coordinates rounded to
+// precisionV decimal places (2 by default) in the index.
+type PathsIndexD struct {
+	index *PathsIndex64
+	paths PathsD
+	scale float64
+}
+
+func NewPathsIndexD(paths PathsD, precisionV ...int) *PathsIndexD {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+	checkPrecision(precision)
+	scale := math.Pow(10, float64(precision))
+
+	return &PathsIndexD{
+		index: NewPathsIndex64(ScalePathsDToPaths64(paths, scale)),
+		paths: slices.Clone(paths),
+		scale: scale,
+	}
+}
+
+func (idx *PathsIndexD) Count() int {
+	return len(idx.paths)
+}
+
+func (idx *PathsIndexD) Path(i int) PathD {
+	return idx.paths[i]
+}
+
+func (idx *PathsIndexD) Insert(path PathD) int {
+	idx.paths = append(idx.paths, path)
+	return idx.index.Insert(ScalePathDToPath64(path, idx.scale))
+}
+
+func (idx *PathsIndexD) Query(rect RectD) []int {
+	return idx.index.Query(NewRect64(
+		int64(math.Floor(rect.left*idx.scale)), int64(math.Floor(rect.top*idx.scale)),
+		int64(math.Ceil(rect.right*idx.scale)), int64(math.Ceil(rect.bottom*idx.scale)),
+	))
+}
+
+func (idx *PathsIndexD) QueryPaths(rect RectD) PathsD {
+	found := idx.Query(rect)
+	result := make(PathsD, len(found))
+	for i, j := range found {
+		result[i] = idx.paths[j]
+	}
+	return result
+}
+
+func (idx *PathsIndexD) Nearest(pt PointD) (int, float64) {
+	i, dist := idx.index.Nearest(NewFloatPoint64(pt.X*idx.scale, pt.Y*idx.scale))
+	return i, dist / idx.scale
+}
+
+// pathDistanceSqr64 returns the squared distance from pt to the closed path,
+// 0 if pt is inside it.
+func pathDistanceSqr64(pt Point64, path Path64) float64 {
+	if len(path) == 0 {
+		return math.Inf(1)
+	}
+	if PointInPolygon(pt, path) != IsOutside {
+		return 0
+	}
+
+	result := math.Inf(1)
+	prev := path[len(path)-1]
+	for _, curr := range path {
+		result = math.Min(result, segmentDistanceSqr64(pt, prev, curr))
+		prev = curr
+	}
+	return result
+}
+
+func segmentDistanceSqr64(pt, a, b Point64) float64 {
+	dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+	px, py := float64(pt.X-a.X), float64(pt.Y-a.Y)
+	if lenSqr := dx*dx + dy*dy; lenSqr > 0 {
+		t := math.Max(0, math.Min(1, (px*dx+py*dy)/lenSqr))
+		px, py = px-t*dx, py-t*dy
+	}
+	return px*px + py*py
+}
+
+func rectDistanceSqr64(pt Point64, rect Rect64) float64 {
+	dx := float64(max(rect.left-pt.X, 0, pt.X-rect.right))
+	dy := float64(max(rect.top-pt.Y, 0, pt.Y-rect.bottom))
+	return dx*dx + dy*dy
+}
@@ -0,0 +1,118 @@
+package go_clipper2_test
+
+import (
+	"math"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathsIndex64(t *testing.T) {
+	// a scattered set of small diamonds and circles
+	paths := goclipper2.Paths64{}
+	for i := int64(0); i < 500; i++ {
+		x, y := (i*7919)%1000, (i*104729)%1000
+		if i%2 == 0 {
+			paths = append(paths, goclipper2.MakePath64(x, y-5, x+5, y, x, y+5, x-5, y))
+		} else {
+			paths = append(paths, goclipper2.Ellipse64(goclipper2.Point64{X: x, Y: y}, 8, 8, 0))
+		}
+	}
+
+	idx := goclipper2.NewPathsIndex64(paths[:400])
+	for _, path := range paths[400:] {
+		idx.Insert(path)
+	}
+	assert.Equal(t, len(paths), idx.Count())
+
+	rects := []goclipper2.Rect64{
+		goclipper2.NewRect64(0, 0, 100, 100),
+		goclipper2.NewRect64(250, 400, 600, 450),
+		goclipper2.NewRect64(-50, -50, 1050, 1050),
+		goclipper2.NewRect64(2000, 2000, 3000, 3000),
+	}
+	for _, rect := range rects {
+		expect := []int{}
+		for i, path := range paths {
+			bounds := goclipper2.GetBounds64(path)
+			if bounds.Intersects(rect) {
+				expect = append(expect, i)
+			}
+		}
+		assert.Equal(t, expect, idx.Query(rect))
+		assert.Equal(t, len(expect), len(idx.QueryPaths(rect)))
+	}
+
+	for _, pt := range []goclipper2.Point64{{X: 500, Y: 500}, {X: -100, Y: 300}, {X: 1234, Y: 987}, paths[17][0]} {
+		best, dist := idx.Nearest(pt)
+		expect := math.Inf(1)
+		for _, path := range paths {
+			expect = math.Min(expect, distanceToPolygon64(pt, path))
+		}
+		assert.InDelta(t, expect, dist, 1e-9, "point %v", pt)
+		assert.InDelta(t, expect, distanceToPolygon64(pt, idx.Path(best)), 1e-9, "point %v", pt)
+	}
+
+	empty := goclipper2.NewPathsIndex64(nil)
+	best, _ := empty.Nearest(goclipper2.Point64{})
+	assert.Equal(t, -1, best)
+	assert.Equal(t, []int{}, empty.Query(goclipper2.NewRect64(0, 0, 10, 10)))
+}
+
+func TestPathsIndexD(t *testing.T) {
+	paths := goclipper2.PathsD{
+		goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 0, 1),
+		goclipper2.MakePathD(2, 0, 3, 0, 3, 1, 2, 1),
+	}
+	idx := goclipper2.NewPathsIndexD(paths)
+	idx.Insert(goclipper2.MakePathD(0, 2, 1, 2, 1, 3, 0, 3))
+
+	assert.Equal(t, []int{0, 1}, idx.Query(goclipper2.NewRectD(0.5, 0.5, 2.5, 0.6)))
+	assert.Equal(t, goclipper2.PathsD{paths[1]}, idx.QueryPaths(goclipper2.NewRectD(2.5, -1, 4, 0.5)))
+
+	best, dist := idx.Nearest(goclipper2.PointD{X: 0.5, Y: 4})
+	assert.Equal(t, 2, best)
+	assert.InDelta(t, 1, dist, 1e-9)
+	best, dist = idx.Nearest(goclipper2.PointD{X: 2.5, Y: 0.5})
+	assert.Equal(t, 1, best)
+	assert.Equal(t, 0.0, dist)
+}
+
+func distanceToPolygon64(pt goclipper2.Point64, path goclipper2.Path64) float64 {
+	if goclipper2.PointInPolygon(pt, path) != goclipper2.IsOutside {
+		return 0
+	}
+	result := math.Inf(1)
+	for i := range path {
+		a, b := path[i], path[(i+1)%len(path)]
+		dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+		t := ((float64(pt.X-a.X))*dx + (float64(pt.Y-a.Y))*dy) / (dx*dx + dy*dy)
+		t = math.Max(0, math.Min(1, t))
+		result = math.Min(result, math.Hypot(float64(a.X)+t*dx-float64(pt.X), float64(a.Y)+t*dy-float64(pt.Y)))
+	}
+	return result
+}
+
+func BenchmarkPathsIndex64(b *testing.B) {
+	paths := goclipper2.Paths64{}
+	for x := int64(0); x < 100; x++ {
+		for y := int64(0); y < 100; y++ {
+			paths = append(paths, goclipper2.Ellipse64(goclipper2.Point64{X: x * 100, Y: y * 100}, 40, 40, 0))
+		}
+	}
+	idx := goclipper2.NewPathsIndex64(paths)
+	rect := goclipper2.NewRect64(4000, 4000, 4500, 4500)
+
+	b.Run("query", func(b *testing.B) {
+		for b.Loop() {
+			_ = idx.Query(rect)
+		}
+	})
+
+	b.Run("nearest", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = idx.Nearest(goclipper2.Point64{X: 5050, Y: 5050})
+		}
+	})
+}
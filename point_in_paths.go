@@ -0,0 +1,257 @@
+package go_clipper2
+
+import (
+	"math"
+)
+
+// PointInPaths64 classifies pt against paths as a whole, adding up the winding
+// numbers of every path and applying fillRule, so points in holes and in
+// overlapping paths are handled. IsOn is returned if pt lies on any edge.
+func PointInPaths64(pt Point64, paths Paths64, fillRule FillRule) PointInPolygonResult {
+	winding := 0
+	for _, path := range paths {
+		w, on := windingNumber64(pt, path)
+		if on {
+			return IsOn
+		}
+		winding += w
+	}
+	if isFilled(winding, fillRule) {
+		return IsInside
+	}
+	return IsOutside
+}
+
+// PointInPathsD is PointInPaths64 for PathsD, with coordinates rounded to
+// precisionV decimal places (2 by default).
+func PointInPathsD(pt PointD, paths PathsD, fillRule FillRule, precisionV ...int) PointInPolygonResult {
+	scale := pointInPathsScale(precisionV...)
+	return PointInPaths64(NewFloatPoint64(pt.X*scale, pt.Y*scale), ScalePathsDToPaths64(paths, scale), fillRule)
+}
+
+// LocatePolyTree64 returns the deepest node of polytree whose polygon contains
+// pt (on its boundary counts as inside), or nil if no outer polygon does. The
+// node's IsHole tells whether pt is inside the filled region.
+func LocatePolyTree64(polytree *PolyTree64, pt Point64) *PolyPath64 {
+	pp := locatePolyPath(polytree.PolyPathBase, pt)
+	if pp == nil {
+		return nil
+	}
+	return &PolyPath64{pp}
+}
+
+// LocatePolyTreeD is LocatePolyTree64 for a PolyTreeD, with pt in the same
+// units as the paths the tree was built from.
+func LocatePolyTreeD(polytree *PolyTreeD, pt PointD) *PolyPathD {
+	scale := polytree.Scale()
+	if scale == 0 {
+		scale = 1
+	}
+	pp := locatePolyPath(polytree.PolyPathBase, NewFloatPoint64(pt.X*scale, pt.Y*scale))
+	if pp == nil {
+		return nil
+	}
+	return &PolyPathD{pp}
+}
+
+func locatePolyPath(pp *PolyPathBase, pt Point64) *PolyPathBase {
+	var result *PolyPathBase
+	for children := pp.GetChildren(); len(children) > 0; {
+		next := children
+		children = nil
+		for _, child := range next {
+			if PointInPolygon(pt, child.Polygon()) != IsOutside {
+				result = child
+				children = child.GetChildren()
+				break
+			}
+		}
+	}
+	return result
+}
+
+// PointInPathsIndex64 answers PointInPaths64 queries for many points. The
+// edges of the paths are bucketed into horizontal bands, so each query only
+// visits the edges spanning its band.
+type PointInPathsIndex64 struct {
+	fillRule FillRule
+	top      int64
+	bottom   int64
+	bandSize float64
+	bands    [][]edge64
+}
+
+type edge64 struct {
+	a, b Point64
+}
+
+func NewPointInPathsIndex64(paths Paths64, fillRule FillRule) *PointInPathsIndex64 {
+	idx := &PointInPathsIndex64{fillRule: fillRule}
+
+	edges := make([]edge64, 0)
+	idx.top, idx.bottom = math.MaxInt64, math.MinInt64
+	for _, path := range paths {
+		if len(path) < 3 {
+			continue
+		}
+		prev := path[len(path)-1]
+		for _, pt := range path {
+			edges = append(edges, edge64{prev, pt})
+			idx.top, idx.bottom = min(idx.top, pt.Y), max(idx.bottom, pt.Y)
+			prev = pt
+		}
+	}
+	if len(edges) == 0 {
+		return idx
+	}
+
+	// roughly a few edges per band for evenly spread edges
+	bandCnt := max(1, len(edges)/4)
+	idx.bandSize = max(1, float64(idx.bottom-idx.top+1)/float64(bandCnt))
+	idx.bands = make([][]edge64, int(float64(idx.bottom-idx.top)/idx.bandSize)+1)
+	for _, e := range edges {
+		first := idx.band(min(e.a.Y, e.b.Y))
+		last := idx.band(max(e.a.Y, e.b.Y))
+		for i := first; i <= last; i++ {
+			idx.bands[i] = append(idx.bands[i], e)
+		}
+	}
+	return idx
+}
+
+func (idx *PointInPathsIndex64) band(y int64) int {
+	return min(int(float64(y-idx.top)/idx.bandSize), len(idx.bands)-1)
+}
+
+// Query returns the same result as PointInPaths64 for the indexed paths.
+func (idx *PointInPathsIndex64) Query(pt Point64) PointInPolygonResult {
+	if len(idx.bands) == 0 || pt.Y < idx.top || pt.Y > idx.bottom {
+		if isFilled(0, idx.fillRule) {
+			return IsInside
+		}
+		return IsOutside
+	}
+
+	winding := 0
+	for _, e := range idx.bands[idx.band(pt.Y)] {
+		w, on := edgeWinding64(pt, e.a, e.b)
+		if on {
+			return IsOn
+		}
+		winding += w
+	}
+	if isFilled(winding, idx.fillRule) {
+		return IsInside
+	}
+	return IsOutside
+}
+
+// QueryPoints classifies every point of pts.
+func (idx *PointInPathsIndex64) QueryPoints(pts Path64) []PointInPolygonResult {
+	result := make([]PointInPolygonResult, len(pts))
+	for i, pt := range pts {
+		result[i] = idx.Query(pt)
+	}
+	return result
+}
+
+type PointInPathsIndexD struct {
+	index *PointInPathsIndex64
+	scale float64
+}
+
+func NewPointInPathsIndexD(paths PathsD, fillRule FillRule, precisionV ...int) *PointInPathsIndexD {
+	scale := pointInPathsScale(precisionV...)
+	return &PointInPathsIndexD{
+		index: NewPointInPathsIndex64(ScalePathsDToPaths64(paths, scale), fillRule),
+		scale: scale,
+	}
+}
+
+func (idx *PointInPathsIndexD) Query(pt PointD) PointInPolygonResult {
+	return idx.index.Query(NewFloatPoint64(pt.X*idx.scale, pt.Y*idx.scale))
+}
+
+func (idx *PointInPathsIndexD) QueryPoints(pts PathD) []PointInPolygonResult {
+	result := make([]PointInPolygonResult, len(pts))
+	for i, pt := range pts {
+		result[i] = idx.Query(pt)
+	}
+	return result
+}
+
+func pointInPathsScale(precisionV ...int) float64 {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+	checkPrecision(precision)
+	return math.Pow(10, float64(precision))
+}
+
+// windingNumber64 returns the winding number of path around pt, positive for
+// positively oriented paths, and whether pt lies on one of its edges.
+func windingNumber64(pt Point64, path Path64) (int, bool) {
+	if len(path) < 3 {
+		return 0, false
+	}
+
+	result := 0
+	prev := path[len(path)-1]
+	for _, curr := range path {
+		w, on := edgeWinding64(pt, prev, curr)
+		if on {
+			return 0, true
+		}
+		result += w
+		prev = curr
+	}
+	return result, false
+}
+
+// edgeWinding64 returns the contribution of the edge a->b to the winding
+// number around pt: +1 if it crosses pt's horizontal upwards to the right of
+// pt, -1 if it crosses downwards, else 0. It also reports whether pt lies on
+// the edge.
+func edgeWinding64(pt, a, b Point64) (int, bool) {
+	if (pt.Y < a.Y && pt.Y < b.Y) || (pt.Y > a.Y && pt.Y > b.Y) ||
+		(pt.X < a.X && pt.X < b.X && pt.Y != a.Y && pt.Y != b.Y) {
+		// can't touch pt and, if it crosses at all, it crosses to the right
+		if a.Y <= pt.Y && b.Y > pt.Y {
+			return 1, false
+		}
+		if a.Y > pt.Y && b.Y <= pt.Y {
+			return -1, false
+		}
+		return 0, false
+	}
+	if pt.X > a.X && pt.X > b.X {
+		return 0, false
+	}
+
+	d := CrossProduct(a, b, pt)
+	if d == 0 && pt.X >= min(a.X, b.X) && pt.X <= max(a.X, b.X) &&
+		pt.Y >= min(a.Y, b.Y) && pt.Y <= max(a.Y, b.Y) {
+		return 0, true
+	}
+	if a.Y <= pt.Y && b.Y > pt.Y && d > 0 {
+		return 1, false
+	}
+	if a.Y > pt.Y && b.Y <= pt.Y && d < 0 {
+		return -1, false
+	}
+	return 0, false
+}
+
+func isFilled(winding int, fillRule FillRule) bool {
+	switch fillRule {
+	case EvenOdd:
+		return winding&1 != 0
+	case Positive:
+		return winding > 0
+	case Negative:
+		return winding < 0
+	default:
+		return winding != 0
+	}
+}
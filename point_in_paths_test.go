@@ -0,0 +1,168 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointInPaths64(t *testing.T) {
+	var (
+		// two overlapping squares, then a square with a clockwise hole
+		overlapping = goclipper2.Paths64{
+			goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+			goclipper2.MakePath64(50, 50, 150, 50, 150, 150, 50, 150),
+		}
+		withHole = goclipper2.Paths64{
+			goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+			goclipper2.MakePath64(25, 25, 25, 75, 75, 75, 75, 25),
+		}
+		clockwise = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 0, 100, 100, 100, 100, 0)}
+	)
+
+	in, out, on := goclipper2.IsInside, goclipper2.IsOutside, goclipper2.IsOn
+	tests := []struct {
+		name   string
+		paths  goclipper2.Paths64
+		pt     goclipper2.Point64
+		expect [4]goclipper2.PointInPolygonResult // EvenOdd, NonZero, Positive, Negative
+	}{
+		{name: "overlap", paths: overlapping, pt: goclipper2.Point64{X: 75, Y: 75}, expect: [4]goclipper2.PointInPolygonResult{out, in, in, out}},
+		{name: "single", paths: overlapping, pt: goclipper2.Point64{X: 25, Y: 25}, expect: [4]goclipper2.PointInPolygonResult{in, in, in, out}},
+		{name: "outside", paths: overlapping, pt: goclipper2.Point64{X: 125, Y: 25}, expect: [4]goclipper2.PointInPolygonResult{out, out, out, out}},
+		{name: "on edge", paths: overlapping, pt: goclipper2.Point64{X: 100, Y: 75}, expect: [4]goclipper2.PointInPolygonResult{on, on, on, on}},
+		{name: "on vertex", paths: overlapping, pt: goclipper2.Point64{X: 50, Y: 50}, expect: [4]goclipper2.PointInPolygonResult{on, on, on, on}},
+		{name: "hole", paths: withHole, pt: goclipper2.Point64{X: 50, Y: 50}, expect: [4]goclipper2.PointInPolygonResult{out, out, out, out}},
+		{name: "around hole", paths: withHole, pt: goclipper2.Point64{X: 10, Y: 50}, expect: [4]goclipper2.PointInPolygonResult{in, in, in, out}},
+		{name: "level with vertex", paths: withHole, pt: goclipper2.Point64{X: 10, Y: 25}, expect: [4]goclipper2.PointInPolygonResult{in, in, in, out}},
+		{name: "clockwise", paths: clockwise, pt: goclipper2.Point64{X: 50, Y: 50}, expect: [4]goclipper2.PointInPolygonResult{in, in, out, in}},
+	}
+
+	fillRules := []goclipper2.FillRule{goclipper2.EvenOdd, goclipper2.NonZero, goclipper2.Positive, goclipper2.Negative}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			for j, fillRule := range fillRules {
+				assert.Equal(t, tt.expect[j], goclipper2.PointInPaths64(tt.pt, tt.paths, fillRule), "fill rule %d", fillRule)
+				idx := goclipper2.NewPointInPathsIndex64(tt.paths, fillRule)
+				assert.Equal(t, tt.expect[j], idx.Query(tt.pt), "index, fill rule %d", fillRule)
+			}
+		})
+	}
+}
+
+func TestPointInPathsIndex64(t *testing.T) {
+	paths := goclipper2.Paths64{
+		goclipper2.Ellipse64(goclipper2.Point64{X: 500, Y: 500}, 400, 300, 0),
+		goclipper2.MakePath64(100, 100, 900, 500, 100, 900, 500, 100, 500, 900),
+		goclipper2.ReversePath(goclipper2.Ellipse64(goclipper2.Point64{X: 500, Y: 500}, 100, 100, 0)),
+	}
+
+	pts := goclipper2.Path64{}
+	for x := int64(0); x <= 1000; x += 7 {
+		for y := int64(0); y <= 1000; y += 7 {
+			pts = append(pts, goclipper2.Point64{X: x, Y: y})
+		}
+	}
+
+	for _, fillRule := range []goclipper2.FillRule{goclipper2.EvenOdd, goclipper2.NonZero, goclipper2.Positive, goclipper2.Negative} {
+		results := goclipper2.NewPointInPathsIndex64(paths, fillRule).QueryPoints(pts)
+		for i, pt := range pts {
+			if !assert.Equal(t, goclipper2.PointInPaths64(pt, paths, fillRule), results[i], "point %v, fill rule %d", pt, fillRule) {
+				return
+			}
+		}
+	}
+
+	// a single path agrees with PointInPolygon
+	for _, pt := range pts {
+		assert.Equal(t, goclipper2.PointInPolygon(pt, paths[0]), goclipper2.PointInPaths64(pt, paths[:1], goclipper2.NonZero))
+	}
+}
+
+func TestLocatePolyTree64(t *testing.T) {
+	paths := goclipper2.Paths64{
+		goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+		goclipper2.MakePath64(20, 20, 80, 20, 80, 80, 20, 80),
+		goclipper2.MakePath64(40, 40, 60, 40, 60, 60, 40, 60),
+		goclipper2.MakePath64(200, 0, 300, 0, 300, 100, 200, 100),
+	}
+	polytree := goclipper2.BooleanOpPolyTree64(goclipper2.Union, paths, nil, goclipper2.EvenOdd)
+
+	tests := []struct {
+		name   string
+		pt     goclipper2.Point64
+		level  int
+		isHole bool
+	}{
+		{name: "outer", pt: goclipper2.Point64{X: 10, Y: 10}, level: 1},
+		{name: "hole", pt: goclipper2.Point64{X: 30, Y: 30}, level: 2, isHole: true},
+		{name: "island", pt: goclipper2.Point64{X: 50, Y: 50}, level: 3},
+		{name: "second outer", pt: goclipper2.Point64{X: 250, Y: 50}, level: 1},
+		{name: "on hole boundary", pt: goclipper2.Point64{X: 20, Y: 50}, level: 2, isHole: true},
+		{name: "outside", pt: goclipper2.Point64{X: 150, Y: 50}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			pp := goclipper2.LocatePolyTree64(polytree, tt.pt)
+			if tt.level == 0 {
+				assert.Nil(t, pp)
+				return
+			}
+			assert.Equal(t, tt.level, pp.Level())
+			assert.Equal(t, tt.isHole, pp.IsHole())
+			assert.Equal(t, tt.level-1, pp.Parent().Level())
+		})
+	}
+}
+
+func TestPointInPathsD(t *testing.T) {
+	paths := goclipper2.PathsD{
+		goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 0, 1),
+		goclipper2.MakePathD(0.25, 0.25, 0.25, 0.75, 0.75, 0.75, 0.75, 0.25),
+	}
+
+	assert.Equal(t, goclipper2.IsInside, goclipper2.PointInPathsD(goclipper2.PointD{X: 0.1, Y: 0.5}, paths, goclipper2.NonZero))
+	assert.Equal(t, goclipper2.IsOutside, goclipper2.PointInPathsD(goclipper2.PointD{X: 0.5, Y: 0.5}, paths, goclipper2.NonZero))
+	assert.Equal(t, goclipper2.IsOn, goclipper2.PointInPathsD(goclipper2.PointD{X: 0.25, Y: 0.5}, paths, goclipper2.NonZero))
+
+	idx := goclipper2.NewPointInPathsIndexD(paths, goclipper2.NonZero)
+	results := idx.QueryPoints(goclipper2.PathD{{X: 0.1, Y: 0.5}, {X: 0.5, Y: 0.5}, {X: 2, Y: 2}})
+	assert.Equal(t, []goclipper2.PointInPolygonResult{goclipper2.IsInside, goclipper2.IsOutside, goclipper2.IsOutside}, results)
+
+	polytree := goclipper2.BooleanOpPolyTreeD(goclipper2.Union, paths, nil, goclipper2.NonZero)
+	pp := goclipper2.LocatePolyTreeD(polytree, goclipper2.PointD{X: 0.5, Y: 0.5})
+	assert.True(t, pp.IsHole())
+	assert.InDelta(t, 0.25, math.Abs(goclipper2.AreaD(pp.PolygonD())), 1e-9)
+	assert.Nil(t, goclipper2.LocatePolyTreeD(polytree, goclipper2.PointD{X: 2, Y: 2}))
+}
+
+func BenchmarkPointInPaths64(b *testing.B) {
+	paths := goclipper2.Paths64{
+		goclipper2.Ellipse64(goclipper2.Point64{X: 5000, Y: 5000}, 4000, 3000, 2048),
+		goclipper2.Ellipse64(goclipper2.Point64{X: 5000, Y: 5000}, 1000, 1000, 2048),
+	}
+	pts := goclipper2.Path64{}
+	for x := int64(0); x < 10000; x += 100 {
+		for y := int64(0); y < 10000; y += 100 {
+			pts = append(pts, goclipper2.Point64{X: x, Y: y})
+		}
+	}
+
+	b.Run("paths", func(b *testing.B) {
+		for b.Loop() {
+			for _, pt := range pts {
+				_ = goclipper2.PointInPaths64(pt, paths, goclipper2.EvenOdd)
+			}
+		}
+	})
+
+	b.Run("index", func(b *testing.B) {
+		for b.Loop() {
+			_ = goclipper2.NewPointInPathsIndex64(paths, goclipper2.EvenOdd).QueryPoints(pts)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package go_clipper2
+
+import (
+	"container/heap"
+	"math"
+)
+
+// PoleOfInaccessibility64 returns the point inside the polygon of a polytree
+// node, less its holes, that is furthest from the outline, together with that
+// distance. It's a better spot for a label than the centroid, which may fall
+// in a hole or outside a concave polygon. The search stops once no point can
+// be more than precision further from the outline than the one found.
+func PoleOfInaccessibility64(pp *PolyPathBase, precision float64) (Point64, float64) {
+	pole, dist := poleOfInaccessibility(Paths64ToPathsD(pp.region()), precision)
+	return NewFloatPoint64(pole.X, pole.Y), dist
+}
+
+// PoleOfInaccessibilityD is PoleOfInaccessibility64 for a node of a PolyTreeD,
+// with precision in the units of the paths the tree was built from.
+func PoleOfInaccessibilityD(pp *PolyPathBase, precision float64) (PointD, float64) {
+	scale := pp.treeScale()
+	return poleOfInaccessibility(ScalePaths64ToPathsD(pp.region(), 1/scale), precision)
+}
+
+// poleCell is a square of the polylabel search: its centre, half its side and
+// the distance from the centre to the outline, negative outside.
+type poleCell struct {
+	x, y, h float64
+	dist    float64
+}
+
+func newPoleCell(x, y, h float64, rings PathsD) poleCell {
+	return poleCell{x: x, y: y, h: h, dist: ringsSignedDistance(x, y, rings)}
+}
+
+// potential returns the furthest from the outline any point of the cell can be.
+func (c poleCell) potential() float64 {
+	return c.dist + c.h*math.Sqrt2
+}
+
+// poleOfInaccessibility covers the bounds of rings with square cells and
+// splits them best potential first, dropping the cells that can't beat the
+// best centre found by more than precision (Agafonkin's polylabel).
+func poleOfInaccessibility(rings PathsD, precision float64) (PointD, float64) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, ring := range rings {
+		for _, pt := range ring {
+			minX, minY = math.Min(minX, pt.X), math.Min(minY, pt.Y)
+			maxX, maxY = math.Max(maxX, pt.X), math.Max(maxY, pt.Y)
+		}
+	}
+	if math.IsInf(minX, 1) {
+		return PointD{}, 0
+	}
+	size := math.Min(maxX-minX, maxY-minY)
+	if size == 0 {
+		return PointD{X: minX, Y: minY}, 0
+	}
+	// a precision of 0 would split cells down to rounding errors
+	precision = math.Max(precision, size*1e-9)
+
+	queue := &poleQueue{}
+	h := size / 2
+	for x := minX; x < maxX; x += size {
+		for y := minY; y < maxY; y += size {
+			heap.Push(queue, newPoleCell(x+h, y+h, h, rings))
+		}
+	}
+
+	centroid := CentroidPathsD(rings)
+	best := newPoleCell(centroid.X, centroid.Y, 0, rings)
+	if mid := newPoleCell((minX+maxX)/2, (minY+maxY)/2, 0, rings); mid.dist > best.dist {
+		best = mid
+	}
+
+	for queue.Len() > 0 {
+		cell := heap.Pop(queue).(poleCell)
+		if cell.dist > best.dist {
+			best = cell
+		}
+		// the queue is ordered by potential, so no cell left can do better
+		if cell.potential()-best.dist <= precision {
+			break
+		}
+		h = cell.h / 2
+		heap.Push(queue, newPoleCell(cell.x-h, cell.y-h, h, rings))
+		heap.Push(queue, newPoleCell(cell.x+h, cell.y-h, h, rings))
+		heap.Push(queue, newPoleCell(cell.x-h, cell.y+h, h, rings))
+		heap.Push(queue, newPoleCell(cell.x+h, cell.y+h, h, rings))
+	}
+	return PointD{X: best.x, Y: best.y}, best.dist
+}
+
+// ringsSignedDistance returns the distance from (x, y) to the nearest edge of
+// rings, negated if the point is outside them (even-odd).
+func ringsSignedDistance(x, y float64, rings PathsD) float64 {
+	inside := false
+	minDistSqr := math.Inf(1)
+	for _, ring := range rings {
+		if len(ring) == 0 {
+			continue
+		}
+		prev := ring[len(ring)-1]
+		for _, pt := range ring {
+			if (pt.Y > y) != (prev.Y > y) && x < (prev.X-pt.X)*(y-pt.Y)/(prev.Y-pt.Y)+pt.X {
+				inside = !inside
+			}
+			minDistSqr = math.Min(minDistSqr, segmentDistanceSqrD(x, y, pt, prev))
+			prev = pt
+		}
+	}
+	if inside {
+		return math.Sqrt(minDistSqr)
+	}
+	return -math.Sqrt(minDistSqr)
+}
+
+func segmentDistanceSqrD(x, y float64, a, b PointD) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	px, py := x-a.X, y-a.Y
+	if lenSqr := dx*dx + dy*dy; lenSqr > 0 {
+		t := math.Max(0, math.Min(1, (px*dx+py*dy)/lenSqr))
+		px, py = px-t*dx, py-t*dy
+	}
+	return px*px + py*py
+}
+
+// poleQueue is a max heap of cells by potential.
+type poleQueue []poleCell
+
+func (q poleQueue) Len() int           { return len(q) }
+func (q poleQueue) Less(i, j int) bool { return q[i].potential() > q[j].potential() }
+func (q poleQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *poleQueue) Push(x any)        { *q = append(*q, x.(poleCell)) }
+func (q *poleQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
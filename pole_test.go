@@ -0,0 +1,70 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoleOfInaccessibility64(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths goclipper2.Paths64
+		pole  goclipper2.Point64
+		dist  float64
+	}{
+		{
+			name:  "square",
+			paths: goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)},
+			pole:  goclipper2.Point64{X: 50, Y: 50},
+			dist:  50,
+		},
+		{
+			// the centroid is in the gap between the arms, the pole is in a
+			// corner of the base, 100/(1+1/√2) from both sides and the arm
+			name:  "u shape",
+			paths: goclipper2.Paths64{goclipper2.MakePath64(0, 0, 300, 0, 300, 300, 200, 300, 200, 100, 100, 100, 100, 300, 0, 300)},
+			dist:  58.58,
+		},
+		{
+			name: "hole",
+			paths: goclipper2.Paths64{
+				goclipper2.MakePath64(0, 0, 350, 0, 350, 200, 0, 200),
+				goclipper2.MakePath64(50, 50, 50, 150, 150, 150, 150, 50),
+			},
+			pole: goclipper2.Point64{X: 250, Y: 100},
+			dist: 100,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			polytree := goclipper2.BooleanOpPolyTree64(goclipper2.Union, tt.paths, nil, goclipper2.NonZero)
+			node := polytree.Child(0).PolyPathBase
+			pole, dist := goclipper2.PoleOfInaccessibility64(node, 0.5)
+
+			assert.InDelta(t, tt.dist, dist, 0.5)
+			assert.Equal(t, goclipper2.IsInside, goclipper2.PointInPaths64(pole, tt.paths, goclipper2.NonZero))
+			if tt.pole != (goclipper2.Point64{}) {
+				assert.InDelta(t, tt.pole.X, pole.X, 1)
+				assert.InDelta(t, tt.pole.Y, pole.Y, 1)
+			}
+			_, centroidDist := goclipper2.ClosestPoint64(goclipper2.NewFloatPoint64(node.Centroid().X, node.Centroid().Y), tt.paths, true)
+			assert.GreaterOrEqual(t, dist, centroidDist-0.5)
+		})
+	}
+
+	pole, dist := goclipper2.PoleOfInaccessibility64(goclipper2.NewPolyTree64().PolyPathBase, 1)
+	assert.Equal(t, goclipper2.Point64{}, pole)
+	assert.Equal(t, 0.0, dist)
+}
+
+func TestPoleOfInaccessibilityD(t *testing.T) {
+	polytree := goclipper2.BooleanOpPolyTreeD(goclipper2.Union, goclipper2.PathsD{goclipper2.MakePathD(0, 0, 3, 0, 3, 1, 0, 1)}, nil, goclipper2.NonZero)
+	pole, dist := goclipper2.PoleOfInaccessibilityD(polytree.Child(0).PolyPathBase, 0.001)
+	assert.InDelta(t, 0.5, dist, 0.001)
+	assert.InDelta(t, 0.5, pole.Y, 0.001)
+	assert.True(t, pole.X >= 0.5-0.001 && pole.X <= 2.5+0.001)
+}
@@ -14,6 +14,51 @@ type PolyPathD struct {
 	*PolyPathBase
 }
 
+// Children returns the outer polygons of the tree.
+func (p *PolyTreeD) Children() []*PolyPathD {
+	return polyPathsD(p.childs)
+}
+
+// Child returns the i-th of Children. It panics unless 0 <= i < Count(), like
+// indexing Children would.
+func (p *PolyTreeD) Child(i int) *PolyPathD {
+	return &PolyPathD{p.childs[i]}
+}
+
+// Children returns the polygons directly inside p: the holes of an outer
+// polygon or the islands inside a hole.
+func (p *PolyPathD) Children() []*PolyPathD {
+	return polyPathsD(p.childs)
+}
+
+// Child returns the i-th of Children. It panics unless 0 <= i < Count(), like
+// indexing Children would.
+func (p *PolyPathD) Child(i int) *PolyPathD {
+	return &PolyPathD{p.childs[i]}
+}
+
+// Parent returns the node containing p, nil for the root of the tree.
+func (p *PolyPathD) Parent() *PolyPathD {
+	if p.parent == nil {
+		return nil
+	}
+	return &PolyPathD{p.parent}
+}
+
+// PolygonD returns the node's polygon in the units of the paths the tree was
+// built from.
+func (p *PolyPathD) PolygonD() PathD {
+	return ScalePath64ToPathD(p.polygon, 1/p.treeScale())
+}
+
+func polyPathsD(childs []*PolyPathBase) []*PolyPathD {
+	result := make([]*PolyPathD, len(childs))
+	for i, child := range childs {
+		result[i] = &PolyPathD{child}
+	}
+	return result
+}
+
 type PolyTree64 PolyPath64
 
 func NewPolyTree64() *PolyTree64 {
@@ -26,6 +71,45 @@ type PolyPath64 struct {
 	*PolyPathBase
 }
 
+// Children returns the outer polygons of the tree.
+func (p *PolyTree64) Children() []*PolyPath64 {
+	return polyPaths64(p.childs)
+}
+
+// Child returns the i-th of Children. It panics unless 0 <= i < Count(), like
+// indexing Children would.
+func (p *PolyTree64) Child(i int) *PolyPath64 {
+	return &PolyPath64{p.childs[i]}
+}
+
+// Children returns the polygons directly inside p: the holes of an outer
+// polygon or the islands inside a hole.
+func (p *PolyPath64) Children() []*PolyPath64 {
+	return polyPaths64(p.childs)
+}
+
+// Child returns the i-th of Children. It panics unless 0 <= i < Count(), like
+// indexing Children would.
+func (p *PolyPath64) Child(i int) *PolyPath64 {
+	return &PolyPath64{p.childs[i]}
+}
+
+// Parent returns the node containing p, nil for the root of the tree.
+func (p *PolyPath64) Parent() *PolyPath64 {
+	if p.parent == nil {
+		return nil
+	}
+	return &PolyPath64{p.parent}
+}
+
+func polyPaths64(childs []*PolyPathBase) []*PolyPath64 {
+	result := make([]*PolyPath64, len(childs))
+	for i, child := range childs {
+		result[i] = &PolyPath64{child}
+	}
+	return result
+}
+
 type PolyPathBase struct {
 	parent  *PolyPathBase
 	childs  []*PolyPathBase
@@ -40,6 +124,7 @@ func NewPolyPathBase(parent *PolyPathBase) *PolyPathBase {
 func (p *PolyPathBase) AddChild(pth Path64) *PolyPathBase {
 	child := NewPolyPathBase(p)
 	child.polygon = pth
+	child.scale = p.scale
 	p.childs = append(p.childs, child)
 	return child
 }
@@ -52,8 +137,12 @@ func (p *PolyPathBase) Count() int {
 	return len(p.childs)
 }
 
+// SetScale sets the scale of p and every node below it.
 func (p *PolyPathBase) SetScale(scale float64) {
 	p.scale = scale
+	for _, child := range p.childs {
+		child.SetScale(scale)
+	}
 }
 
 func (p *PolyPathBase) Scale() float64 {
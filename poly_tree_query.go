@@ -0,0 +1,202 @@
+package go_clipper2
+
+import (
+	"iter"
+	"math"
+)
+
+// All iterates over the descendants of p, depth first, each node before its
+// children. p itself is not included, so for a polytree these are all of its
+// polygons.
+func (p *PolyPathBase) All() iter.Seq[*PolyPathBase] {
+	return func(yield func(*PolyPathBase) bool) {
+		p.walk(yield)
+	}
+}
+
+func (p *PolyPathBase) walk(yield func(*PolyPathBase) bool) bool {
+	for _, child := range p.childs {
+		if !yield(child) || !child.walk(yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// Outers iterates over the outer polygons below p, at any depth, together
+// with their holes.
+func (p *PolyPathBase) Outers() iter.Seq2[*PolyPathBase, []*PolyPathBase] {
+	return func(yield func(*PolyPathBase, []*PolyPathBase) bool) {
+		for pp := range p.All() {
+			if !pp.IsHole() && !yield(pp, pp.childs) {
+				return
+			}
+		}
+	}
+}
+
+// Area returns the area of the node's polygon less that of its children, in
+// the polygon's integer coordinates. It's positive for an outer polygon less
+// its holes and negative for a hole less the islands inside it.
+func (p *PolyPathBase) Area() float64 {
+	if p.parent == nil {
+		return 0
+	}
+
+	result := math.Abs(Area64(p.polygon))
+	for _, child := range p.childs {
+		result -= math.Abs(Area64(child.polygon))
+	}
+	if p.IsHole() {
+		return -result
+	}
+	return result
+}
+
+// AreaD is Area in the units of the paths a PolyTreeD was built from.
+func (p *PolyPathBase) AreaD() float64 {
+	scale := p.treeScale()
+	return p.Area() / (scale * scale)
+}
+
+// Perimeter returns the length of the outline of the node's polygon and of
+// its children, in the polygon's integer coordinates.
+func (p *PolyPathBase) Perimeter() float64 {
+	return PerimeterPaths64(p.region())
+}
+
+// PerimeterD is Perimeter in the units of the paths a PolyTreeD was built
+// from.
+func (p *PolyPathBase) PerimeterD() float64 {
+	return p.Perimeter() / p.treeScale()
+}
+
+// Centroid returns the centroid of the node's polygon less its children, in
+// the polygon's integer coordinates.
+func (p *PolyPathBase) Centroid() PointD {
+	return CentroidPaths64(p.region())
+}
+
+// CentroidD is Centroid in the units of the paths a PolyTreeD was built from.
+func (p *PolyPathBase) CentroidD() PointD {
+	scale := p.treeScale()
+	pt := p.Centroid()
+	return PointD{X: pt.X / scale, Y: pt.Y / scale}
+}
+
+// SecondMoments returns the second moments of area of the node's polygon
+// less its children about their centroid. Like Area, they're negative for a
+// hole.
+func (p *PolyPathBase) SecondMoments() SecondMoments {
+	result := SecondMoments64(p.region())
+	if p.IsHole() {
+		return SecondMoments{Ixx: -result.Ixx, Iyy: -result.Iyy, Ixy: -result.Ixy}
+	}
+	return result
+}
+
+// SecondMomentsD is SecondMoments in the units of the paths a PolyTreeD was
+// built from.
+func (p *PolyPathBase) SecondMomentsD() SecondMoments {
+	scale4 := math.Pow(p.treeScale(), 4)
+	result := p.SecondMoments()
+	return SecondMoments{Ixx: result.Ixx / scale4, Iyy: result.Iyy / scale4, Ixy: result.Ixy / scale4}
+}
+
+// Compactness is Compactness64 of the node's polygon less its children. It
+// doesn't depend on the scale of the tree.
+func (p *PolyPathBase) Compactness() float64 {
+	return Compactness64(p.region())
+}
+
+// Roundness is Roundness64 of the node's polygon less its children. It
+// doesn't depend on the scale of the tree.
+func (p *PolyPathBase) Roundness() float64 {
+	return Roundness64(p.region())
+}
+
+// region returns the node's polygon positively oriented and its children's
+// negatively, or nothing for the root.
+func (p *PolyPathBase) region() Paths64 {
+	if p.parent == nil {
+		return nil
+	}
+
+	result := make(Paths64, 0, len(p.childs)+1)
+	path := p.polygon
+	if !IsPositive64(path) {
+		path = ReversePath(path)
+	}
+	result = append(result, path)
+	for _, child := range p.childs {
+		path = child.polygon
+		if IsPositive64(path) {
+			path = ReversePath(path)
+		}
+		result = append(result, path)
+	}
+	return result
+}
+
+// treeScale returns the scale of the polytree p belongs to, 1 if it has none.
+func (p *PolyPathBase) treeScale() float64 {
+	if p.scale == 0 {
+		return 1
+	}
+	return p.scale
+}
+
+// PolyTreeToPaths64 returns every polygon of polytree, outer polygons
+// positively oriented and holes negatively, regardless of how they were built.
+func PolyTreeToPaths64(polytree *PolyTree64) Paths64 {
+	return polyTreePaths64(polytree.PolyPathBase)
+}
+
+func PolyTreeToPathsD(polytree *PolyTreeD) PathsD {
+	return ScalePaths64ToPathsD(polyTreePaths64(polytree.PolyPathBase), 1/polytree.treeScale())
+}
+
+func polyTreePaths64(root *PolyPathBase) Paths64 {
+	result := make(Paths64, 0)
+	for pp := range root.All() {
+		path := pp.polygon
+		if IsPositive64(path) == pp.IsHole() {
+			path = ReversePath(path)
+		}
+		result = append(result, path)
+	}
+	return result
+}
+
+// FilterPolyTree64 returns a copy of polytree without the islands (outer
+// polygons at any depth) whose Area is less than minArea. Their holes go
+// with them, but larger islands inside those holes are kept.
+func FilterPolyTree64(polytree *PolyTree64, minArea float64) *PolyTree64 {
+	result := NewPolyTree64()
+	filterPolyPath(polytree.PolyPathBase, result.PolyPathBase, minArea)
+	return result
+}
+
+// FilterPolyTreeD is FilterPolyTree64 with minArea in the units of the paths
+// polytree was built from.
+func FilterPolyTreeD(polytree *PolyTreeD, minArea float64) *PolyTreeD {
+	scale := polytree.treeScale()
+	result := NewPolyTreeD()
+	result.SetScale(polytree.Scale())
+	filterPolyPath(polytree.PolyPathBase, result.PolyPathBase, minArea*scale*scale)
+	return result
+}
+
+// filterPolyPath copies the children of src that are kept into dst.
+func filterPolyPath(src, dst *PolyPathBase, minArea float64) {
+	for _, child := range src.childs {
+		if child.IsHole() || child.Area() >= minArea {
+			filterPolyPath(child, dst.AddChild(child.polygon), minArea)
+			continue
+		}
+		// the island goes, but what's inside its holes stays at the same parity
+		for _, hole := range child.childs {
+			filterPolyPath(hole, dst, minArea)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package go_clipper2_test
+
+import (
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func nestedSquares64() goclipper2.Paths64 {
+	return goclipper2.Paths64{
+		goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+		goclipper2.MakePath64(10, 10, 90, 10, 90, 90, 10, 90),
+		goclipper2.MakePath64(20, 20, 80, 20, 80, 80, 20, 80),
+		goclipper2.MakePath64(30, 30, 70, 30, 70, 70, 30, 70),
+		goclipper2.MakePath64(45, 45, 55, 45, 55, 55, 45, 55),
+		goclipper2.MakePath64(200, 0, 205, 0, 205, 5, 200, 5),
+	}
+}
+
+func TestPolyTreeAll(t *testing.T) {
+	polytree := goclipper2.BooleanOpPolyTree64(goclipper2.Union, nestedSquares64(), nil, goclipper2.EvenOdd)
+
+	levels := []int{}
+	for pp := range polytree.All() {
+		levels = append(levels, pp.Level())
+	}
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 1}, levels)
+
+	// stopping early
+	cnt := 0
+	for range polytree.All() {
+		cnt++
+		if cnt == 2 {
+			break
+		}
+	}
+	assert.Equal(t, 2, cnt)
+
+	areas := map[int]float64{}
+	holes := map[int]int{}
+	for outer, outerHoles := range polytree.Outers() {
+		assert.False(t, outer.IsHole())
+		for _, hole := range outerHoles {
+			assert.True(t, hole.IsHole())
+		}
+		areas[outer.Level()] += outer.Area()
+		holes[outer.Level()] += len(outerHoles)
+	}
+	assert.Equal(t, map[int]float64{1: 100*100 - 80*80 + 5*5, 3: 60*60 - 40*40, 5: 10 * 10}, areas)
+	assert.Equal(t, map[int]int{1: 1, 3: 1, 5: 0}, holes)
+
+	for pp := range polytree.All() {
+		if pp.Level() == 2 {
+			assert.Equal(t, -(80*80 - 60*60.0), pp.Area())
+		}
+	}
+}
+
+func TestPolyTreeToPaths64(t *testing.T) {
+	polytree := goclipper2.BooleanOpPolyTree64(goclipper2.Union, nestedSquares64(), nil, goclipper2.EvenOdd)
+	result := goclipper2.PolyTreeToPaths64(polytree)
+	assert.Equal(t, 6, len(result))
+	assert.InDelta(t, goclipper2.AreaPaths64(goclipper2.UnionPaths64(nestedSquares64(), goclipper2.EvenOdd)), goclipper2.AreaPaths64(result), 1e-9)
+
+	// a tree built by hand with every polygon the wrong way round
+	polytree = goclipper2.NewPolyTree64()
+	outer := polytree.AddChild(goclipper2.MakePath64(0, 0, 0, 100, 100, 100, 100, 0))
+	outer.AddChild(goclipper2.MakePath64(10, 10, 90, 10, 90, 90, 10, 90))
+	result = goclipper2.PolyTreeToPaths64(polytree)
+	assert.True(t, goclipper2.IsPositive64(result[0]))
+	assert.False(t, goclipper2.IsPositive64(result[1]))
+	assert.InDelta(t, 100*100-80*80, goclipper2.AreaPaths64(result), 1e-9)
+	assert.InDelta(t, 100*100-80*80, outer.Area(), 1e-9)
+}
+
+func TestFilterPolyTree64(t *testing.T) {
+	polytree := goclipper2.BooleanOpPolyTree64(goclipper2.Union, nestedSquares64(), nil, goclipper2.EvenOdd)
+
+	// rings of 3600 and 2000 are kept, the squares of 100 and 25 go
+	filtered := goclipper2.FilterPolyTree64(polytree, 1000)
+	levels := []int{}
+	for pp := range filtered.All() {
+		levels = append(levels, pp.Level())
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, levels)
+
+	// a thin ring goes, the island inside its hole takes its place
+	thin := goclipper2.BooleanOpPolyTree64(goclipper2.Union, goclipper2.Paths64{
+		goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+		goclipper2.MakePath64(1, 1, 99, 1, 99, 99, 1, 99),
+		goclipper2.MakePath64(10, 10, 90, 10, 90, 90, 10, 90),
+	}, nil, goclipper2.EvenOdd)
+	filtered = goclipper2.FilterPolyTree64(thin, 1000)
+	assert.Equal(t, 1, filtered.Count())
+	assert.InDelta(t, 80*80, filtered.GetChildren()[0].Area(), 1e-9)
+
+	filtered = goclipper2.FilterPolyTree64(polytree, 4000)
+	assert.Equal(t, 0, filtered.Count())
+	assert.Equal(t, 6, len(goclipper2.PolyTreeToPaths64(polytree)))
+}
+
+func TestPolyTreeQueryD(t *testing.T) {
+	paths := goclipper2.PathsD{
+		goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 0, 1),
+		goclipper2.MakePathD(0.25, 0.25, 0.25, 0.75, 0.75, 0.75, 0.75, 0.25),
+		goclipper2.MakePathD(2, 0, 2.1, 0, 2.1, 0.1, 2, 0.1),
+	}
+	polytree := goclipper2.BooleanOpPolyTreeD(goclipper2.Union, paths, nil, goclipper2.NonZero)
+
+	total := 0.0
+	for outer := range polytree.Outers() {
+		total += outer.AreaD()
+	}
+	assert.InDelta(t, 0.75+0.01, total, 1e-9)
+
+	result := goclipper2.PolyTreeToPathsD(polytree)
+	assert.InDelta(t, 0.75+0.01, goclipper2.AreaPathsD(result), 1e-9)
+
+	filtered := goclipper2.FilterPolyTreeD(polytree, 0.05)
+	assert.Equal(t, 1, filtered.Count())
+	assert.InDelta(t, 0.75, goclipper2.AreaPathsD(goclipper2.PolyTreeToPathsD(filtered)), 1e-9)
+}
@@ -0,0 +1,64 @@
+package go_clipper2_test
+
+import (
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolyTreeDScale(t *testing.T) {
+	subject := goclipper2.PathsD{
+		goclipper2.MakePathD(0, 0, 10, 0, 10, 10, 0, 10),
+		goclipper2.MakePathD(2.5, 2.5, 2.5, 7.5, 7.5, 7.5, 7.5, 2.5),
+		goclipper2.MakePathD(4.25, 4.25, 5.75, 4.25, 5.75, 5.75, 4.25, 5.75),
+		goclipper2.MakePathD(20.125, 0, 30, 0, 25, 8.5),
+	}
+	clip := goclipper2.PathsD{goclipper2.MakePathD(-1, 4, 31, 4, 31, 6, -1, 6)}
+
+	for _, clipType := range []goclipper2.ClipType{goclipper2.Union, goclipper2.Difference, goclipper2.Xor} {
+		expect := goclipper2.BooleanOpPathsD(clipType, subject, clip, goclipper2.EvenOdd, 3)
+		polytree := goclipper2.BooleanOpPolyTreeD(clipType, subject, clip, goclipper2.EvenOdd, 3)
+
+		result := goclipper2.PathsD{}
+		var walk func(children []*goclipper2.PolyPathD)
+		walk = func(children []*goclipper2.PolyPathD) {
+			for _, child := range children {
+				assert.Equal(t, polytree.Scale(), child.Scale())
+				result = append(result, child.PolygonD())
+				walk(child.Children())
+			}
+		}
+		walk(polytree.Children())
+		assert.ElementsMatch(t, expect, result, "clip type %d", clipType)
+	}
+}
+
+func TestPolyTreeTyped(t *testing.T) {
+	paths := goclipper2.Paths64{
+		goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+		goclipper2.MakePath64(20, 20, 80, 20, 80, 80, 20, 80),
+		goclipper2.MakePath64(40, 40, 60, 40, 60, 60, 40, 60),
+	}
+	polytree := goclipper2.BooleanOpPolyTree64(goclipper2.Union, paths, nil, goclipper2.EvenOdd)
+
+	outer := polytree.Child(0)
+	assert.Equal(t, 1, len(polytree.Children()))
+	hole := outer.Child(0)
+	assert.True(t, hole.IsHole())
+	island := hole.Children()[0]
+	assert.Equal(t, 3, island.Level())
+	assert.Equal(t, hole.Polygon(), island.Parent().Polygon())
+	assert.Nil(t, outer.Parent().Parent())
+
+	polytreeD := goclipper2.BooleanOpPolyTreeD(goclipper2.Union, goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 0, 1)}, nil, goclipper2.NonZero)
+	assert.InDelta(t, 1, goclipper2.AreaD(polytreeD.Child(0).PolygonD()), 1e-9)
+	assert.Nil(t, polytreeD.Child(0).Parent().Parent())
+
+	// nodes added after the scale is set inherit it
+	built := goclipper2.NewPolyTreeD()
+	built.SetScale(100)
+	child := built.AddChild(goclipper2.MakePath64(0, 0, 100, 0, 100, 100))
+	assert.Equal(t, 100.0, child.Scale())
+	assert.Equal(t, goclipper2.MakePathD(0, 0, 1, 0, 1, 1), built.Child(0).PolygonD())
+}
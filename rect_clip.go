@@ -17,7 +17,7 @@ type OutPt2 struct {
 	prev     *OutPt2
 	pt       Point64
 	ownerIdx int
-	edge     []*OutPt2
+	edge     *[]*OutPt2
 }
 
 func NewOutPt2(pt Point64) *OutPt2 {
@@ -232,10 +232,11 @@ func (r *RectClip64) executeInternal(path Path64) {
 	var ok bool
 	if loc, ok = getLocation(r.rect, path[highI]); !ok {
 		i := highI - 1
-		prev, ok = getLocation(r.rect, path[i])
-		for i >= 0 && !ok {
+		for i >= 0 {
+			if prev, ok = getLocation(r.rect, path[i]); ok {
+				break
+			}
 			i--
-			prev, ok = getLocation(r.rect, path[i])
 		}
 		if i < 0 {
 			for _, pt := range path {
@@ -253,7 +254,7 @@ func (r *RectClip64) executeInternal(path Path64) {
 	i := 0
 	for i <= highI {
 		prev = loc
-		prevCrossLoc := Inside
+		prevCrossLoc := crossingLoc
 		r.getNextLocation(path, &loc, &i, highI)
 		if i > highI {
 			break
@@ -384,10 +385,11 @@ func (r *RectClip64) executeInternalPath64(path Path64) {
 	var loc Location
 	var ok bool
 	if loc, ok = getLocation(r.rect, path[0]); !ok {
-		prev, ok2 := getLocation(r.rect, path[i])
-		for i <= highI && !ok2 {
+		for i <= highI {
+			if prev, ok = getLocation(r.rect, path[i]); ok {
+				break
+			}
 			i++
-			prev, ok2 = getLocation(r.rect, path[i])
 		}
 		if i > highI {
 			for _, pt := range path {
@@ -472,7 +474,7 @@ func (r *RectClip64) checkEdges() {
 
 		r.results[i] = op2
 
-		edgeSet1 := getEdgesForPt(op.pt, r.rect)
+		edgeSet1 := getEdgesForPt(op.prev.pt, r.rect)
 		op2 = op
 
 		for {
@@ -499,15 +501,17 @@ func (r *RectClip64) checkEdges() {
 	}
 }
 
-func (r *RectClip64) tidyEdgePair(idx int, cw, ccw []*OutPt2) {
-	if len(ccw) == 0 {
+func (r *RectClip64) tidyEdgePair(idx int, cwEdge, ccwEdge *[]*OutPt2) {
+	if len(*ccwEdge) == 0 {
 		return
 	}
 	isHorz := idx == 1 || idx == 3
 	cwIsTowardLarger := idx == 1 || idx == 2
 	i, j := 0, 0
 
-	for i < len(cw) {
+	for i < len(*cwEdge) {
+		// addToEdge may grow either edge, so the slices are reloaded each loop
+		cw, ccw := *cwEdge, *ccwEdge
 		p1 := cw[i]
 		if p1 == nil || p1.next == p1.prev {
 			cw[i] = nil
@@ -541,7 +545,7 @@ func (r *RectClip64) tidyEdgePair(idx int, cw, ccw []*OutPt2) {
 		}
 
 		if (isHorz && !hasHorzOverlap(p1.pt, p1a.pt, p2.pt, p2a.pt)) ||
-			(!isHorz && !hasHorzOverlap(p1.pt, p1a.pt, p2.pt, p2a.pt)) {
+			(!isHorz && !hasVertOverlap(p1.pt, p1a.pt, p2.pt, p2a.pt)) {
 			j++
 			continue
 		}
@@ -621,7 +625,7 @@ func (r *RectClip64) tidyEdgePair(idx int, cw, ccw []*OutPt2) {
 			if opIsLarger == cwIsTowardLarger {
 				cw[i] = op
 				uncoupleEdge(op2)
-				addToEdge(&cw, op2)
+				addToEdge(cwEdge, op2)
 				ccw[j] = nil
 				j++
 			} else {
@@ -629,7 +633,7 @@ func (r *RectClip64) tidyEdgePair(idx int, cw, ccw []*OutPt2) {
 				i++
 				ccw[j] = op2
 				uncoupleEdge(op)
-				addToEdge(&ccw, op)
+				addToEdge(ccwEdge, op)
 				j = 0
 			}
 		} else {
@@ -662,7 +666,7 @@ func addToEdge(edge *[]*OutPt2, op *OutPt2) {
 	if op.edge != nil {
 		return
 	}
-	op.edge = *edge
+	op.edge = edge
 	*edge = append(*edge, op)
 }
 
@@ -670,11 +674,11 @@ func uncoupleEdge(op *OutPt2) {
 	if op.edge == nil {
 		return
 	}
-	for i, op2 := range op.edge {
+	for i, op2 := range *op.edge {
 		if op2 != op {
 			continue
 		}
-		op.edge[i] = nil
+		(*op.edge)[i] = nil
 	}
 	op.edge = nil
 }
@@ -971,7 +975,7 @@ func startLocsAreClockwise(startLocs []Location) bool {
 
 func getPathRectClipLine(op *OutPt2) Path64 {
 	var result Path64
-	if op == nil || op.prev == op.next {
+	if op == nil || op == op.next {
 		return result
 	}
 	op = op.next
@@ -1049,7 +1053,7 @@ func RectClipPathsD(rect RectD, paths PathsD, precisionV ...int) PathsD {
 
 	rc := NewRectClip64(r)
 	result := rc.Execute(tmpPaths)
-	return ScalePaths64ToPathsD(result, scale)
+	return ScalePaths64ToPathsD(result, 1/scale)
 }
 
 func RectClipPathD(rect RectD, path PathD) PathsD {
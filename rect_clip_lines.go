@@ -39,7 +39,7 @@ func (r *RectClip64) Execute(paths Paths64) Paths64 {
 		r.checkEdges()
 
 		for i := 0; i < 4; i++ {
-			r.tidyEdgePair(i, r.edges[i*2], r.edges[i*2+1])
+			r.tidyEdgePair(i, &r.edges[i*2], &r.edges[i*2+1])
 		}
 
 		for _, op := range r.results {
@@ -58,6 +58,41 @@ func (r *RectClip64) Execute(paths Paths64) Paths64 {
 	return result
 }
 
+func (r *RectClipLines64) Execute(paths Paths64) Paths64 {
+	result := Paths64{}
+
+	if r.rect.IsEmpty() {
+		return result
+	}
+
+	for _, path := range paths {
+		if len(path) < 2 {
+			continue
+		}
+		r.pathBounds = getBounds(path)
+
+		if !r.rect.Intersects(r.pathBounds) {
+			continue
+		}
+
+		r.executeInternalPath64(path)
+
+		for _, op := range r.results {
+			tmp := r.getPath(op)
+			if len(tmp) > 0 {
+				result = append(result, tmp)
+			}
+		}
+
+		r.results = r.results[:0]
+		for i := 0; i < 8; i++ {
+			r.edges[i] = r.edges[i][:0]
+		}
+	}
+
+	return result
+}
+
 func RectClipLinesPaths64(rect Rect64, paths Paths64) Paths64 {
 	if rect.IsEmpty() || len(paths) == 0 {
 		return Paths64{}
@@ -93,7 +128,7 @@ func RectClipLinesPathsD(rect RectD, paths PathsD, precisionV ...int) PathsD {
 
 	rc := NewRectClipLines64(r)
 	result := rc.Execute(tmpPaths)
-	return ScalePaths64ToPathsD(result, scale)
+	return ScalePaths64ToPathsD(result, 1/scale)
 }
 
 func RectClipLinesPathD(rect RectD, path PathD) PathsD {
@@ -1,6 +1,8 @@
 package go_clipper2_test
 
 import (
+	"fmt"
+	"math"
 	"testing"
 
 	goclipper2 "github.com/bolom009/go-clipper2"
@@ -19,3 +21,68 @@ func TestRectClipPaths64(t *testing.T) {
 	assert.Equal(t, len(solution), 1)
 	assert.EqualValues(t, expect, solution)
 }
+
+func TestRectClipMatchesIntersect64(t *testing.T) {
+	rect := goclipper2.NewRect64(200, 200, 800, 800)
+	tests := []struct {
+		name    string
+		subject goclipper2.Path64
+	}{
+		{name: "covers a corner", subject: goclipper2.MakePath64(715, 390, 714, 898, 108, 931, 359, 390, 158, -64, 573, 126)},
+		{name: "splits on a side", subject: goclipper2.MakePath64(100, 100, 900, 100, 900, 900, 500, 900, 500, 300, 400, 300, 400, 900, 100, 900)},
+		{name: "reversed", subject: goclipper2.ReversePath(goclipper2.MakePath64(100, 100, 900, 100, 900, 900, 500, 900, 500, 300, 400, 300, 400, 900, 100, 900))},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			solution := goclipper2.RectClipPaths64(rect, goclipper2.Paths64{tt.subject})
+			expect := goclipper2.IntersectWithClipPaths64(goclipper2.Paths64{tt.subject}, goclipper2.Paths64{rect.AsPath()}, goclipper2.NonZero)
+			assert.Equal(t, len(expect), len(solution))
+			// rect clipping keeps the subject's orientation and truncates its intersections
+			assert.InDelta(t, math.Abs(goclipper2.AreaPaths64(expect)), math.Abs(goclipper2.AreaPaths64(solution)), 500)
+		})
+	}
+}
+
+func TestRectClipPathsD(t *testing.T) {
+	rect := goclipper2.NewRectD(0, 0, 1, 1)
+	subject := goclipper2.PathsD{goclipper2.MakePathD(0.5, 0.5, 2, 0.5, 2, 2, 0.5, 2)}
+
+	solution := goclipper2.RectClipPathsD(rect, subject)
+
+	assert.Equal(t, 1, len(solution))
+	assert.InDelta(t, 0.25, goclipper2.AreaPathsD(solution), 1e-9)
+}
+
+func TestRectClipLinesPaths64(t *testing.T) {
+	var (
+		rect    = goclipper2.NewRect64(0, 0, 100, 100)
+		subject = goclipper2.Paths64{
+			goclipper2.MakePath64(-10, 50, 110, 50),
+			goclipper2.MakePath64(50, -10, 50, 50, 150, 50),
+			goclipper2.MakePath64(-10, -10, -20, 200),
+			goclipper2.MakePath64(0, 20, 30, 20, 30, 150),
+		}
+		expect = goclipper2.Paths64{
+			{{0, 50}, {100, 50}},
+			{{50, 0}, {50, 50}, {100, 50}},
+			{{0, 20}, {30, 20}, {30, 100}},
+		}
+	)
+
+	solution := goclipper2.RectClipLinesPaths64(rect, subject)
+	assert.EqualValues(t, expect, solution)
+}
+
+func TestGetBounds64(t *testing.T) {
+	bounds := goclipper2.GetBounds64(goclipper2.MakePath64(10, 20, -5, 40, 30, -7))
+	assert.Equal(t, goclipper2.NewRect64(-5, -7, 30, 40), bounds)
+}
+
+func TestRectClipLinesPathsD(t *testing.T) {
+	rect := goclipper2.NewRectD(0, 0, 1, 1)
+	subject := goclipper2.PathsD{goclipper2.MakePathD(-0.5, 0.5, 1.5, 0.5)}
+
+	solution := goclipper2.RectClipLinesPathsD(rect, subject)
+	assert.Equal(t, goclipper2.PathsD{goclipper2.MakePathD(0, 0.5, 1, 0.5)}, solution)
+}
@@ -0,0 +1,234 @@
+package go_clipper2
+
+import (
+	"math"
+	"sort"
+)
+
+// PointAtDistanceD returns the point distance along path from its first
+// vertex. On an open path distance is clamped to the path's ends, on a closed
+// one it wraps around, so a negative distance is measured back from the
+// start.
+func PointAtDistanceD(path PathD, distance float64, isClosedPath bool) PointD {
+	if len(path) == 0 {
+		return PointD{}
+	}
+	walker := newPathWalkerD(path, isClosedPath)
+	return walker.at(walker.clamp(distance))
+}
+
+func PointAtDistance64(path Path64, distance float64, isClosedPath bool) Point64 {
+	pt := PointAtDistanceD(Path64ToPathD(path), distance, isClosedPath)
+	return pt.ToPoint64()
+}
+
+// ResamplePathD returns points spacing apart along path, starting at its
+// first vertex. An open path keeps its last vertex too, however close it is
+// to the point before it. A spacing that isn't positive returns path as it is.
+func ResamplePathD(path PathD, spacing float64, isClosedPath bool) PathD {
+	if spacing <= 0 || len(path) < 2 {
+		return path
+	}
+	walker := newPathWalkerD(path, isClosedPath)
+	length := walker.length()
+
+	result := make(PathD, 0, int(length/spacing)+2)
+	for i := 0; float64(i)*spacing < length; i++ {
+		result = append(result, walker.at(float64(i)*spacing))
+	}
+	if !isClosedPath {
+		result = append(result, path[len(path)-1])
+	}
+	return result
+}
+
+func ResamplePath64(path Path64, spacing float64, isClosedPath bool) Path64 {
+	return StripDuplicates(pathDToPath64Rounded(ResamplePathD(Path64ToPathD(path), spacing, isClosedPath)), isClosedPath)
+}
+
+// ResamplePathByCountD returns count points evenly spaced along path. Those of
+// an open path include both its ends.
+func ResamplePathByCountD(path PathD, count int, isClosedPath bool) PathD {
+	if len(path) == 0 || count < 1 {
+		return PathD{}
+	}
+	walker := newPathWalkerD(path, isClosedPath)
+	if count == 1 {
+		return PathD{path[0]}
+	}
+
+	intervals := count - 1
+	if isClosedPath {
+		intervals = count
+	}
+	spacing := walker.length() / float64(intervals)
+	result := make(PathD, count)
+	for i := range result {
+		result[i] = walker.at(float64(i) * spacing)
+	}
+	if !isClosedPath {
+		result[count-1] = path[len(path)-1]
+	}
+	return result
+}
+
+func ResamplePathByCount64(path Path64, count int, isClosedPath bool) Path64 {
+	return pathDToPath64Rounded(ResamplePathByCountD(Path64ToPathD(path), count, isClosedPath))
+}
+
+// SubPathD returns the open path that runs along path from distance from to
+// distance to, with the vertices in between. On an open path both are
+// clamped to its ends and from > to gives the piece reversed. On a closed one
+// they wrap around, from > to runs on past the first vertex, and to at least
+// a perimeter beyond from gives the whole loop.
+func SubPathD(path PathD, from, to float64, isClosedPath bool) PathD {
+	if len(path) == 0 {
+		return PathD{}
+	}
+	walker := newPathWalkerD(path, isClosedPath)
+	length := walker.length()
+
+	reverse := false
+	if isClosedPath {
+		span := to - from
+		if span < length {
+			span = math.Mod(span, length)
+			if span < 0 {
+				span += length
+			}
+		} else {
+			span = length
+		}
+		from = walker.clamp(from)
+		to = from + span
+	} else {
+		from, to = walker.clamp(from), walker.clamp(to)
+		if from > to {
+			from, to, reverse = to, from, true
+		}
+	}
+
+	result := PathD{walker.at(from)}
+	// the vertices strictly between the two distances, once round at most
+	for lap := 0.0; lap <= to; lap += length {
+		for i, d := range walker.dists {
+			if d+lap > from && d+lap < to {
+				result = append(result, walker.pts[i])
+			}
+		}
+		if length == 0 {
+			break
+		}
+	}
+	if to > length {
+		to -= length
+	}
+	result = append(result, walker.at(to))
+	result = stripDuplicatesD(result, false)
+	if reverse {
+		return ReversePath(result)
+	}
+	return result
+}
+
+func SubPath64(path Path64, from, to float64, isClosedPath bool) Path64 {
+	return StripDuplicates(pathDToPath64Rounded(SubPathD(Path64ToPathD(path), from, to, isClosedPath)), false)
+}
+
+// DensifyPathD adds vertices to path so that no segment is longer than
+// maxSegmentLength, splitting each long segment into equal parts. The
+// original vertices are all kept, which makes it the complement of
+// SimplifyPathD.
+func DensifyPathD(path PathD, maxSegmentLength float64, isClosedPath bool) PathD {
+	if maxSegmentLength <= 0 || len(path) < 2 {
+		return path
+	}
+
+	result := make(PathD, 0, len(path))
+	cnt := len(path)
+	for i := 0; i < cnt; i++ {
+		result = append(result, path[i])
+		if i == cnt-1 && !isClosedPath {
+			break
+		}
+		a, b := path[i], path[(i+1)%cnt]
+		steps := int(math.Ceil(math.Hypot(b.X-a.X, b.Y-a.Y) / maxSegmentLength))
+		for j := 1; j < steps; j++ {
+			result = append(result, lerpPointD(a, b, float64(j)/float64(steps)))
+		}
+	}
+	return result
+}
+
+func DensifyPath64(path Path64, maxSegmentLength float64, isClosedPath bool) Path64 {
+	return StripDuplicates(pathDToPath64Rounded(DensifyPathD(Path64ToPathD(path), maxSegmentLength, isClosedPath)), isClosedPath)
+}
+
+func DensifyPaths64(paths Paths64, maxSegmentLength float64, isClosedPaths bool) Paths64 {
+	result := make(Paths64, len(paths))
+	for i, path := range paths {
+		result[i] = DensifyPath64(path, maxSegmentLength, isClosedPaths)
+	}
+	return result
+}
+
+func DensifyPathsD(paths PathsD, maxSegmentLength float64, isClosedPaths bool) PathsD {
+	result := make(PathsD, len(paths))
+	for i, path := range paths {
+		result[i] = DensifyPathD(path, maxSegmentLength, isClosedPaths)
+	}
+	return result
+}
+
+// pathWalkerD holds the vertices of a path, the first repeated at the end if
+// it's closed, and their distances along it.
+type pathWalkerD struct {
+	pts      PathD
+	dists    []float64
+	isClosed bool
+}
+
+func newPathWalkerD(path PathD, isClosedPath bool) *pathWalkerD {
+	pts := path
+	if isClosedPath {
+		pts = append(path[:len(path):len(path)], path[0])
+	}
+	dists := make([]float64, len(pts))
+	for i := 1; i < len(pts); i++ {
+		dists[i] = dists[i-1] + math.Hypot(pts[i].X-pts[i-1].X, pts[i].Y-pts[i-1].Y)
+	}
+	return &pathWalkerD{pts: pts, dists: dists, isClosed: isClosedPath}
+}
+
+func (w *pathWalkerD) length() float64 {
+	return w.dists[len(w.dists)-1]
+}
+
+// clamp brings distance into [0, length], wrapping it round a closed path.
+func (w *pathWalkerD) clamp(distance float64) float64 {
+	length := w.length()
+	if w.isClosed && length > 0 {
+		distance = math.Mod(distance, length)
+		if distance < 0 {
+			distance += length
+		}
+		return distance
+	}
+	return math.Max(0, math.Min(length, distance))
+}
+
+// at returns the point distance along the path, which must be in range.
+func (w *pathWalkerD) at(distance float64) PointD {
+	i := sort.SearchFloat64s(w.dists, distance)
+	if i == 0 {
+		return w.pts[0]
+	}
+	if i == len(w.dists) {
+		return w.pts[len(w.pts)-1]
+	}
+	seg := w.dists[i] - w.dists[i-1]
+	if seg == 0 {
+		return w.pts[i]
+	}
+	return lerpPointD(w.pts[i-1], w.pts[i], (distance-w.dists[i-1])/seg)
+}
@@ -0,0 +1,98 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointAtDistance64(t *testing.T) {
+	path := goclipper2.MakePath64(0, 0, 100, 0, 100, 100)
+
+	tests := []struct {
+		distance     float64
+		isClosedPath bool
+		expect       goclipper2.Point64
+	}{
+		{distance: 0, expect: goclipper2.Point64{X: 0, Y: 0}},
+		{distance: 30, expect: goclipper2.Point64{X: 30, Y: 0}},
+		{distance: 100, expect: goclipper2.Point64{X: 100, Y: 0}},
+		{distance: 150, expect: goclipper2.Point64{X: 100, Y: 50}},
+		{distance: 500, expect: goclipper2.Point64{X: 100, Y: 100}},
+		{distance: -10, expect: goclipper2.Point64{X: 0, Y: 0}},
+		// the closing segment is 100√2 long
+		{distance: 200 + 50*1.41421356, isClosedPath: true, expect: goclipper2.Point64{X: 50, Y: 50}},
+		{distance: -30 * 1.41421356, isClosedPath: true, expect: goclipper2.Point64{X: 30, Y: 30}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%v", i, tt.distance), func(t *testing.T) {
+			assert.Equal(t, tt.expect, goclipper2.PointAtDistance64(path, tt.distance, tt.isClosedPath))
+		})
+	}
+
+	pt := goclipper2.PointAtDistanceD(goclipper2.MakePathD(0, 0, 0.3, 0.4), 0.25, false)
+	assert.InDelta(t, 0.15, pt.X, 1e-12)
+	assert.InDelta(t, 0.2, pt.Y, 1e-12)
+	assert.Equal(t, goclipper2.PointD{}, goclipper2.PointAtDistanceD(nil, 1, false))
+}
+
+func TestResamplePath64(t *testing.T) {
+	path := goclipper2.MakePath64(0, 0, 100, 0, 100, 50)
+
+	assert.Equal(t, goclipper2.MakePath64(0, 0, 40, 0, 80, 0, 100, 20, 100, 50), goclipper2.ResamplePath64(path, 40, false))
+	assert.Equal(t, goclipper2.MakePath64(0, 0, 60, 0, 100, 20, 73, 37, 20, 10), goclipper2.ResamplePath64(path, 60, true))
+	assert.Equal(t, path, goclipper2.ResamplePath64(path, 0, false))
+
+	assert.Equal(t, goclipper2.MakePath64(0, 0, 50, 0, 100, 0, 100, 50), goclipper2.ResamplePathByCount64(path, 4, false))
+	square := goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)
+	assert.Equal(t, goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100), goclipper2.ResamplePathByCount64(square, 4, true))
+	assert.Equal(t, goclipper2.MakePath64(0, 0, 100, 100), goclipper2.ResamplePathByCount64(square, 2, true))
+
+	resampled := goclipper2.ResamplePathD(goclipper2.MakePathD(0, 0, 1, 0), 0.3, false)
+	assert.Equal(t, 5, len(resampled))
+	assert.InDelta(t, 0.9, resampled[3].X, 1e-12)
+	assert.Equal(t, goclipper2.PointD{X: 1, Y: 0}, resampled[4])
+}
+
+func TestSubPath64(t *testing.T) {
+	path := goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)
+
+	tests := []struct {
+		name         string
+		from, to     float64
+		isClosedPath bool
+		expect       goclipper2.Path64
+	}{
+		{name: "within a segment", from: 10, to: 60, expect: goclipper2.MakePath64(10, 0, 60, 0)},
+		{name: "across vertices", from: 50, to: 250, expect: goclipper2.MakePath64(50, 0, 100, 0, 100, 100, 50, 100)},
+		{name: "clamped", from: -50, to: 120, expect: goclipper2.MakePath64(0, 0, 100, 0, 100, 20)},
+		{name: "reversed", from: 120, to: 50, expect: goclipper2.MakePath64(100, 20, 100, 0, 50, 0)},
+		{name: "closed wrapping", from: 350, to: 50, isClosedPath: true, expect: goclipper2.MakePath64(0, 50, 0, 0, 50, 0)},
+		{name: "closed whole", from: 0, to: 400, isClosedPath: true, expect: goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100, 0, 0)},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			assert.Equal(t, tt.expect, goclipper2.SubPath64(path, tt.from, tt.to, tt.isClosedPath))
+		})
+	}
+}
+
+func TestDensifyPath64(t *testing.T) {
+	path := goclipper2.MakePath64(0, 0, 100, 0, 100, 30)
+
+	assert.Equal(t, goclipper2.MakePath64(0, 0, 25, 0, 50, 0, 75, 0, 100, 0, 100, 15, 100, 30), goclipper2.DensifyPath64(path, 25, false))
+	closed := goclipper2.DensifyPath64(path, 50, true)
+	assert.Equal(t, goclipper2.MakePath64(0, 0, 50, 0, 100, 0, 100, 30, 67, 20, 33, 10), closed)
+	assert.Equal(t, path, goclipper2.DensifyPath64(path, 0, false))
+
+	// simplifying undoes it
+	assert.Equal(t, path, goclipper2.SimplifyPath64(goclipper2.DensifyPath64(path, 7, false), 0.5, false))
+
+	densified := goclipper2.DensifyPathsD(goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0)}, 0.3, false)
+	assert.Equal(t, 5, len(densified[0]))
+	assert.Equal(t, 2, len(goclipper2.DensifyPaths64(goclipper2.Paths64{path, path}, 10, true)))
+}
@@ -0,0 +1,326 @@
+package go_clipper2
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+type SmoothingOption func(*smoothingConfig)
+
+type smoothingConfig struct {
+	untangle  bool
+	precision int
+}
+
+// WithUntangle unions the smoothed closed paths (NonZero), so that none of
+// them crosses itself or another. Open paths are split instead wherever they
+// cross themselves or each other, so the pieces only meet at their ends.
+func WithUntangle() SmoothingOption {
+	return func(config *smoothingConfig) {
+		config.untangle = true
+	}
+}
+
+// WithSmoothingPrecision sets the decimal places the D variants round to when
+// untangling, 2 by default.
+func WithSmoothingPrecision(precision int) SmoothingOption {
+	return func(config *smoothingConfig) {
+		config.precision = precision
+	}
+}
+
+// ChaikinD cuts every corner of path iterations times, replacing each segment
+// by the points a quarter and three quarters along it (Chaikin's algorithm).
+// The result tends to a quadratic B-spline and stays inside the convex hull of
+// the original. The ends of an open path are kept. Each iteration doubles the
+// number of points.
+func ChaikinD(path PathD, iterations int, isClosedPath bool) PathD {
+	for ; iterations > 0 && len(path) > 2; iterations-- {
+		cnt := len(path)
+		result := make(PathD, 0, 2*cnt)
+		if !isClosedPath {
+			result = append(result, path[0])
+		}
+		for i := 0; i < cnt; i++ {
+			if !isClosedPath && i == cnt-1 {
+				break
+			}
+			a, b := path[i], path[(i+1)%cnt]
+			if isClosedPath || i > 0 {
+				result = append(result, lerpPointD(a, b, 0.25))
+			}
+			if isClosedPath || i < cnt-2 {
+				result = append(result, lerpPointD(a, b, 0.75))
+			}
+		}
+		if !isClosedPath {
+			result = append(result, path[cnt-1])
+		}
+		path = result
+	}
+	return path
+}
+
+func Chaikin64(path Path64, iterations int, isClosedPath bool) Path64 {
+	return StripDuplicates(pathDToPath64Rounded(ChaikinD(Path64ToPathD(path), iterations, isClosedPath)), isClosedPath)
+}
+
+// CatmullRomD fits a centripetal Catmull-Rom spline through the vertices of
+// path and flattens it to within tolerance, which has the same meaning as for
+// CubicBezierD. The centripetal form doesn't overshoot or loop where
+// vertices are unevenly spaced, as in GPS traces. An open path's spline runs
+// from its first vertex to its last.
+func CatmullRomD(path PathD, tolerance float64, isClosedPath bool) PathD {
+	path = stripDuplicatesD(path, isClosedPath)
+	cnt := len(path)
+	if cnt < 3 {
+		return path
+	}
+
+	at := func(i int) PointD {
+		if isClosedPath {
+			return path[(i+cnt)%cnt]
+		}
+		return path[max(0, min(cnt-1, i))]
+	}
+
+	segCnt := cnt - 1
+	if isClosedPath {
+		segCnt = cnt
+	}
+	result := PathD{path[0]}
+	for i := 0; i < segCnt; i++ {
+		p0, p1, p2, p3 := at(i-1), at(i), at(i+1), at(i+2)
+		d1 := math.Sqrt(math.Hypot(p1.X-p0.X, p1.Y-p0.Y))
+		d2 := math.Sqrt(math.Hypot(p2.X-p1.X, p2.Y-p1.Y))
+		d3 := math.Sqrt(math.Hypot(p3.X-p2.X, p3.Y-p2.Y))
+
+		// the Bezier control points of the segment from p1 to p2 (Yuksel et al.)
+		c1, c2 := p1, p2
+		if d1 > 0 {
+			c1 = PointD{
+				X: (d1*d1*p2.X - d2*d2*p0.X + (2*d1*d1+3*d1*d2+d2*d2)*p1.X) / (3 * d1 * (d1 + d2)),
+				Y: (d1*d1*p2.Y - d2*d2*p0.Y + (2*d1*d1+3*d1*d2+d2*d2)*p1.Y) / (3 * d1 * (d1 + d2)),
+			}
+		}
+		if d3 > 0 {
+			c2 = PointD{
+				X: (d3*d3*p1.X - d2*d2*p3.X + (2*d3*d3+3*d3*d2+d2*d2)*p2.X) / (3 * d3 * (d3 + d2)),
+				Y: (d3*d3*p1.Y - d2*d2*p3.Y + (2*d3*d3+3*d3*d2+d2*d2)*p2.Y) / (3 * d3 * (d3 + d2)),
+			}
+		}
+		result = appendCubicBezierD(result, p1, c1, c2, p2, tolerance)
+	}
+	if isClosedPath {
+		result = result[:len(result)-1]
+	}
+	return result
+}
+
+func CatmullRom64(path Path64, tolerance float64, isClosedPath bool) Path64 {
+	return StripDuplicates(pathDToPath64Rounded(CatmullRomD(Path64ToPathD(path), tolerance, isClosedPath)), isClosedPath)
+}
+
+// BSplineD treats the vertices of path as the control points of a uniform
+// cubic B-spline and flattens it to within tolerance. The spline is smoother
+// than CatmullRomD's but only passes near the vertices, except for the ends
+// of an open path which it keeps.
+func BSplineD(path PathD, tolerance float64, isClosedPath bool) PathD {
+	path = stripDuplicatesD(path, isClosedPath)
+	cnt := len(path)
+	if cnt < 3 {
+		return path
+	}
+
+	// an open spline is clamped by repeating its end points
+	ctrl := path
+	if isClosedPath {
+		ctrl = append(PathD{path[cnt-1]}, path...)
+		ctrl = append(ctrl, path[0], path[1])
+	} else {
+		ctrl = append(PathD{path[0], path[0]}, path...)
+		ctrl = append(ctrl, path[cnt-1], path[cnt-1])
+	}
+
+	var result PathD
+	for i := 0; i+3 < len(ctrl); i++ {
+		p0, p1, p2, p3 := ctrl[i], ctrl[i+1], ctrl[i+2], ctrl[i+3]
+		b0 := PointD{X: (p0.X + 4*p1.X + p2.X) / 6, Y: (p0.Y + 4*p1.Y + p2.Y) / 6}
+		b1 := PointD{X: (2*p1.X + p2.X) / 3, Y: (2*p1.Y + p2.Y) / 3}
+		b2 := PointD{X: (p1.X + 2*p2.X) / 3, Y: (p1.Y + 2*p2.Y) / 3}
+		b3 := PointD{X: (p1.X + 4*p2.X + p3.X) / 6, Y: (p1.Y + 4*p2.Y + p3.Y) / 6}
+		if result == nil {
+			result = PathD{b0}
+		}
+		result = appendCubicBezierD(result, b0, b1, b2, b3, tolerance)
+	}
+	if isClosedPath {
+		result = result[:len(result)-1]
+	}
+	return result
+}
+
+func BSpline64(path Path64, tolerance float64, isClosedPath bool) Path64 {
+	return StripDuplicates(pathDToPath64Rounded(BSplineD(Path64ToPathD(path), tolerance, isClosedPath)), isClosedPath)
+}
+
+// ChaikinPaths64 applies Chaikin64 to every path.
+func ChaikinPaths64(paths Paths64, iterations int, isClosedPath bool, opts ...SmoothingOption) Paths64 {
+	return smoothPaths64(paths, isClosedPath, func(path Path64) Path64 {
+		return Chaikin64(path, iterations, isClosedPath)
+	}, opts...)
+}
+
+func ChaikinPathsD(paths PathsD, iterations int, isClosedPath bool, opts ...SmoothingOption) PathsD {
+	return smoothPathsD(paths, isClosedPath, func(path PathD) PathD {
+		return ChaikinD(path, iterations, isClosedPath)
+	}, opts...)
+}
+
+// CatmullRomPaths64 applies CatmullRom64 to every path.
+func CatmullRomPaths64(paths Paths64, tolerance float64, isClosedPath bool, opts ...SmoothingOption) Paths64 {
+	return smoothPaths64(paths, isClosedPath, func(path Path64) Path64 {
+		return CatmullRom64(path, tolerance, isClosedPath)
+	}, opts...)
+}
+
+func CatmullRomPathsD(paths PathsD, tolerance float64, isClosedPath bool, opts ...SmoothingOption) PathsD {
+	return smoothPathsD(paths, isClosedPath, func(path PathD) PathD {
+		return CatmullRomD(path, tolerance, isClosedPath)
+	}, opts...)
+}
+
+// BSplinePaths64 applies BSpline64 to every path.
+func BSplinePaths64(paths Paths64, tolerance float64, isClosedPath bool, opts ...SmoothingOption) Paths64 {
+	return smoothPaths64(paths, isClosedPath, func(path Path64) Path64 {
+		return BSpline64(path, tolerance, isClosedPath)
+	}, opts...)
+}
+
+func BSplinePathsD(paths PathsD, tolerance float64, isClosedPath bool, opts ...SmoothingOption) PathsD {
+	return smoothPathsD(paths, isClosedPath, func(path PathD) PathD {
+		return BSplineD(path, tolerance, isClosedPath)
+	}, opts...)
+}
+
+func smoothPaths64(paths Paths64, isClosedPath bool, smooth func(Path64) Path64, opts ...SmoothingOption) Paths64 {
+	cfg := &smoothingConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	result := make(Paths64, 0, len(paths))
+	for _, path := range paths {
+		result = append(result, smooth(path))
+	}
+	switch {
+	case cfg.untangle && isClosedPath:
+		return UnionPaths64(result, NonZero)
+	case cfg.untangle:
+		return untangleLines64(result)
+	}
+	return result
+}
+
+func smoothPathsD(paths PathsD, isClosedPath bool, smooth func(PathD) PathD, opts ...SmoothingOption) PathsD {
+	cfg := &smoothingConfig{
+		precision: 2,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	result := make(PathsD, 0, len(paths))
+	for _, path := range paths {
+		result = append(result, smooth(path))
+	}
+	switch {
+	case cfg.untangle && isClosedPath:
+		return UnionPathsD(result, NonZero, cfg.precision)
+	case cfg.untangle:
+		checkPrecision(cfg.precision)
+		scale := math.Pow(10, float64(cfg.precision))
+		return ScalePaths64ToPathsD(untangleLines64(ScalePathsDToPaths64(result, scale)), 1/scale)
+	}
+	return result
+}
+
+// untangleLines64 splits the open paths wherever they cross or touch
+// themselves or each other, away from the joins of consecutive segments.
+func untangleLines64(paths Paths64) Paths64 {
+	type lineSeg struct {
+		edge64
+		path, i int
+	}
+	paths = slices.Clone(paths)
+	segs := make([]lineSeg, 0)
+	for p, path := range paths {
+		path = StripDuplicates(path, false)
+		paths[p] = path
+		for i := 1; i < len(path); i++ {
+			segs = append(segs, lineSeg{edge64{path[i-1], path[i]}, p, i - 1})
+		}
+	}
+
+	// sweep along X so only segments with overlapping bounds are compared
+	splits := make([][]Point64, len(segs))
+	order := make([]int, len(segs))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(i, j int) int {
+		return cmp.Compare(min(segs[i].a.X, segs[i].b.X), min(segs[j].a.X, segs[j].b.X))
+	})
+	for k, i := range order {
+		right := max(segs[i].a.X, segs[i].b.X)
+		for _, j := range order[k+1:] {
+			if min(segs[j].a.X, segs[j].b.X) > right {
+				break
+			}
+			s, t := segs[i], segs[j]
+			if s.path == t.path && (s.i == t.i+1 || t.i == s.i+1) {
+				continue
+			}
+			intersectSegs64(s.edge64, t.edge64, &splits[i], &splits[j])
+		}
+	}
+
+	// the segments are in path order, so k follows them along the paths
+	result := make(Paths64, 0, len(paths))
+	k := 0
+	for _, path := range paths {
+		if len(path) < 2 {
+			if len(path) > 0 {
+				result = append(result, path)
+			}
+			continue
+		}
+		piece := Path64{path[0]}
+		for range len(path) - 1 {
+			s, pts := segs[k], splits[k]
+			k++
+			sortAlong64(pts, s.a, s.b)
+			for _, pt := range slices.Compact(pts) {
+				if pt != piece[len(piece)-1] {
+					piece = append(piece, pt)
+				}
+				if pt != path[len(path)-1] && len(piece) > 1 {
+					result = append(result, piece)
+					piece = Path64{pt}
+				}
+			}
+			if s.b != piece[len(piece)-1] {
+				piece = append(piece, s.b)
+			}
+		}
+		if len(piece) > 1 {
+			result = append(result, piece)
+		}
+	}
+	return result
+}
+
+func lerpPointD(a, b PointD, t float64) PointD {
+	return PointD{X: a.X + (b.X-a.X)*t, Y: a.Y + (b.Y-a.Y)*t}
+}
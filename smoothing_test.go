@@ -0,0 +1,111 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaikin64(t *testing.T) {
+	square := goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)
+
+	result := goclipper2.Chaikin64(square, 1, true)
+	assert.Equal(t, goclipper2.MakePath64(25, 0, 75, 0, 100, 25, 100, 75, 75, 100, 25, 100, 0, 75, 0, 25), result)
+	assert.Equal(t, 16, len(goclipper2.Chaikin64(square, 2, true)))
+	assert.Equal(t, square, goclipper2.Chaikin64(square, 0, true))
+
+	open := goclipper2.Chaikin64(goclipper2.MakePath64(0, 0, 100, 0, 100, 100), 1, false)
+	assert.Equal(t, goclipper2.MakePath64(0, 0, 75, 0, 100, 25, 100, 100), open)
+
+	openD := goclipper2.ChaikinD(goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 2, 1), 3, false)
+	assert.Equal(t, goclipper2.PointD{X: 0, Y: 0}, openD[0])
+	assert.Equal(t, goclipper2.PointD{X: 2, Y: 1}, openD[len(openD)-1])
+}
+
+func TestSplines64(t *testing.T) {
+	zigzag := goclipper2.MakePath64(0, 0, 100, 100, 200, 0, 300, 100, 400, 0)
+	square := goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)
+
+	tests := []struct {
+		name         string
+		smooth       func(goclipper2.Path64, float64, bool) goclipper2.Path64
+		smoothD      func(goclipper2.PathD, float64, bool) goclipper2.PathD
+		path         goclipper2.Path64
+		isClosedPath bool
+		interpolates bool
+	}{
+		{name: "catmull-rom open", smooth: goclipper2.CatmullRom64, smoothD: goclipper2.CatmullRomD, path: zigzag, interpolates: true},
+		{name: "catmull-rom closed", smooth: goclipper2.CatmullRom64, smoothD: goclipper2.CatmullRomD, path: square, isClosedPath: true, interpolates: true},
+		{name: "b-spline open", smooth: goclipper2.BSpline64, smoothD: goclipper2.BSplineD, path: zigzag},
+		{name: "b-spline closed", smooth: goclipper2.BSpline64, smoothD: goclipper2.BSplineD, path: square, isClosedPath: true},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			result := tt.smooth(tt.path, 0.5, tt.isClosedPath)
+			assert.Greater(t, len(result), 4*len(tt.path))
+			if !tt.isClosedPath {
+				assert.Equal(t, tt.path[0], result[0])
+				assert.Equal(t, tt.path[len(tt.path)-1], result[len(result)-1])
+			}
+			for _, pt := range tt.path {
+				if tt.interpolates {
+					assert.Contains(t, result, pt)
+				} else if tt.isClosedPath {
+					assert.NotContains(t, result, pt)
+				}
+			}
+
+			// the flattened curve stays within tolerance (and rounding) of a finer one
+			finer := tt.smoothD(goclipper2.Path64ToPathD(tt.path), 0.05, tt.isClosedPath)
+			dist := goclipper2.HausdorffDistanceD(goclipper2.PathsD{goclipper2.Path64ToPathD(result)}, goclipper2.PathsD{finer}, tt.isClosedPath, 3)
+			assert.LessOrEqual(t, dist, 1.5)
+		})
+	}
+
+	// a b-spline bulges less than a catmull-rom spline
+	assert.Less(t, goclipper2.Area64(goclipper2.BSpline64(square, 0.5, true)), goclipper2.Area64(square))
+	assert.Greater(t, goclipper2.Area64(goclipper2.CatmullRom64(square, 0.5, true)), goclipper2.Area64(square))
+}
+
+func TestSmoothingUntangle64(t *testing.T) {
+	bowtie := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 100, 100, 0, 0, 100)}
+
+	tangled := goclipper2.ChaikinPaths64(bowtie, 2, true)
+	assert.Equal(t, 1, len(tangled))
+	assert.InDelta(t, 0, goclipper2.AreaPaths64(tangled), 100)
+
+	result := goclipper2.ChaikinPaths64(bowtie, 2, true, goclipper2.WithUntangle())
+	assert.Equal(t, 2, len(result))
+	for _, path := range result {
+		assert.True(t, goclipper2.IsPositive64(path))
+	}
+
+	assert.Equal(t, 2, len(goclipper2.CatmullRomPaths64(bowtie, 0.5, true, goclipper2.WithUntangle())))
+	assert.Equal(t, 2, len(goclipper2.BSplinePaths64(bowtie, 0.5, true, goclipper2.WithUntangle())))
+
+	// open paths are split where they cross, into pieces that only meet at their ends
+	assert.Equal(t, 1, len(goclipper2.ChaikinPaths64(bowtie, 2, false)))
+	open := goclipper2.ChaikinPaths64(bowtie, 2, false, goclipper2.WithUntangle())
+	assert.Equal(t, 3, len(open))
+	assert.Equal(t, goclipper2.Point64{}, open[0][0])
+	assert.Equal(t, goclipper2.Point64{X: 0, Y: 100}, open[2][len(open[2])-1])
+	assert.Equal(t, open[0][len(open[0])-1], open[1][0])
+	assert.Equal(t, open[1][len(open[1])-1], open[2][0])
+	assert.Equal(t, open[0][len(open[0])-1], open[2][0])
+
+	// and where they cross each other
+	lines := goclipper2.Paths64{goclipper2.MakePath64(0, 50, 50, 60, 100, 50), goclipper2.MakePath64(50, 0, 40, 50, 50, 100)}
+	assert.Equal(t, 4, len(goclipper2.CatmullRomPaths64(lines, 0.5, false, goclipper2.WithUntangle())))
+
+	bowtieD := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 1, 1, 0, 0, 1)}
+	resultD := goclipper2.CatmullRomPathsD(bowtieD, 0.01, true, goclipper2.WithUntangle(), goclipper2.WithSmoothingPrecision(3))
+	assert.Equal(t, 2, len(resultD))
+	openD := goclipper2.CatmullRomPathsD(bowtieD, 0.01, false, goclipper2.WithUntangle(), goclipper2.WithSmoothingPrecision(3))
+	assert.Equal(t, 3, len(openD))
+	assert.Equal(t, goclipper2.PointD{X: 0, Y: 1}, openD[2][len(openD[2])-1])
+	assert.Equal(t, 1, len(goclipper2.BSplinePathsD(bowtieD, 0.01, true)))
+	assert.Equal(t, 1, len(goclipper2.ChaikinPathsD(bowtieD, 1, true)))
+}
@@ -0,0 +1,326 @@
+package go_clipper2
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// SplitPolygonsByLines64 cuts the region polygons fill under fillRule along
+// the open paths lines and returns the pieces, each as an outer path followed
+// by its holes. Lines only cut where they cross the region from boundary to
+// boundary (or to another line); parts that end inside a piece are ignored.
+// The lines are clipped to the region by the engine, so they meet its
+// boundary where a boolean operation would put the crossings, and lines
+// crossing each other meet where the engine would put that crossing too.
+func SplitPolygonsByLines64(polygons, lines Paths64, fillRule FillRule) []Paths64 {
+	region := UnionPaths64(polygons, fillRule)
+	result := make([]Paths64, 0)
+	if len(region) == 0 {
+		return result
+	}
+
+	regionEdges := make([]edge64, 0)
+	for _, path := range region {
+		prev := path[len(path)-1]
+		for _, pt := range path {
+			regionEdges = append(regionEdges, edge64{prev, pt})
+			prev = pt
+		}
+	}
+	segs := slices.Clone(regionEdges)
+	ends := make([]Point64, 0)
+	for _, line := range clipLines64(Intersection, lines, region, NonZero) {
+		for i := 1; i < len(line); i++ {
+			if line[i-1] != line[i] {
+				segs = append(segs, edge64{line[i-1], line[i]})
+			}
+		}
+		ends = append(ends, line[0], line[len(line)-1])
+	}
+
+	g := newPlanarGraph64(segs, len(regionEdges), ends)
+	g.prune()
+
+	inner, outer := g.faces()
+	for _, face := range inner {
+		if !g.inRegion(face) {
+			continue
+		}
+		// rings of other components inside the face are cut out of it
+		holes := make(Paths64, 0)
+		for _, ring := range outer {
+			if PointInPolygon(ring[0], face) == IsInside {
+				holes = append(holes, ring)
+			}
+		}
+		face = TrimCollinear64(face, false)
+		if len(holes) == 0 {
+			result = append(result, Paths64{face})
+			continue
+		}
+		if piece := DifferenceWithClipPaths64(Paths64{face}, holes, NonZero); len(piece) > 0 {
+			result = append(result, piece)
+		}
+	}
+	return result
+}
+
+// SplitPolygonsByLinesD is SplitPolygonsByLines64 for PathsD, with coordinates
+// rounded to precisionV decimal places (2 by default).
+func SplitPolygonsByLinesD(polygons, lines PathsD, fillRule FillRule, precisionV ...int) []PathsD {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+	checkPrecision(precision)
+	scale := math.Pow(10, float64(precision))
+
+	pieces := SplitPolygonsByLines64(ScalePathsDToPaths64(polygons, scale), ScalePathsDToPaths64(lines, scale), fillRule)
+	result := make([]PathsD, len(pieces))
+	for i, piece := range pieces {
+		result[i] = ScalePaths64ToPathsD(piece, 1/scale)
+	}
+	return result
+}
+
+// planarGraph64 is the arrangement of a set of segments: every segment is
+// split where it meets another, and the pieces become edges between their
+// end points. Boundary holds the pieces of the boundary segments, directed
+// as the segments were.
+type planarGraph64 struct {
+	pts      []Point64
+	adj      [][]int
+	boundary map[[2]Point64]bool
+}
+
+// newPlanarGraph64 builds the arrangement of segs. Each of ends that's within
+// rounding of one of the first boundaryCnt segments, without being on it,
+// splits the nearest of them too, so the lines the engine clipped to the
+// boundary stay joined to it.
+func newPlanarGraph64(segs []edge64, boundaryCnt int, ends []Point64) *planarGraph64 {
+	splits := make([][]Point64, len(segs))
+	for i, s := range segs {
+		splits[i] = []Point64{s.a, s.b}
+	}
+	for _, pt := range ends {
+		if i := nearestSeg64(pt, segs[:boundaryCnt]); i >= 0 {
+			splits[i] = append(splits[i], pt)
+		}
+	}
+
+	// sweep along X so only segments with overlapping bounds are compared
+	order := make([]int, len(segs))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(i, j int) int {
+		return cmp.Compare(min(segs[i].a.X, segs[i].b.X), min(segs[j].a.X, segs[j].b.X))
+	})
+	for k, i := range order {
+		right := max(segs[i].a.X, segs[i].b.X)
+		for _, j := range order[k+1:] {
+			if min(segs[j].a.X, segs[j].b.X) > right {
+				break
+			}
+			intersectSegs64(segs[i], segs[j], &splits[i], &splits[j])
+		}
+	}
+
+	g := &planarGraph64{boundary: make(map[[2]Point64]bool)}
+	index := make(map[Point64]int)
+	vertex := func(pt Point64) int {
+		if v, ok := index[pt]; ok {
+			return v
+		}
+		index[pt] = len(g.pts)
+		g.pts = append(g.pts, pt)
+		g.adj = append(g.adj, nil)
+		return len(g.pts) - 1
+	}
+
+	for i, s := range segs {
+		pts := splits[i]
+		sortAlong64(pts, s.a, s.b)
+		pts = slices.Compact(pts)
+		for j := 1; j < len(pts); j++ {
+			if i < boundaryCnt {
+				g.boundary[[2]Point64{pts[j-1], pts[j]}] = true
+			}
+			u, v := vertex(pts[j-1]), vertex(pts[j])
+			if !slices.Contains(g.adj[u], v) {
+				g.adj[u] = append(g.adj[u], v)
+				g.adj[v] = append(g.adj[v], u)
+			}
+		}
+	}
+	return g
+}
+
+// intersectSegs64 adds the points where s and t meet to their split lists.
+// Crossing points come from getSegmentIntersectPt, as in the engine.
+func intersectSegs64(s, t edge64, sSplits, tSplits *[]Point64) {
+	if max(s.a.Y, s.b.Y) < min(t.a.Y, t.b.Y) || min(s.a.Y, s.b.Y) > max(t.a.Y, t.b.Y) {
+		return
+	}
+
+	d1, d2 := CrossProduct(s.a, s.b, t.a), CrossProduct(s.a, s.b, t.b)
+	d3, d4 := CrossProduct(t.a, t.b, s.a), CrossProduct(t.a, t.b, s.b)
+	if d1 == 0 && d2 == 0 {
+		for _, pt := range [2]Point64{t.a, t.b} {
+			if onSegment64(pt, s) {
+				*sSplits = append(*sSplits, pt)
+			}
+		}
+		for _, pt := range [2]Point64{s.a, s.b} {
+			if onSegment64(pt, t) {
+				*tSplits = append(*tSplits, pt)
+			}
+		}
+		return
+	}
+	if (d1 > 0 && d2 > 0) || (d1 < 0 && d2 < 0) || (d3 > 0 && d4 > 0) || (d3 < 0 && d4 < 0) {
+		return
+	}
+
+	switch {
+	case d1 == 0:
+		*sSplits = append(*sSplits, t.a)
+	case d2 == 0:
+		*sSplits = append(*sSplits, t.b)
+	case d3 == 0:
+		*tSplits = append(*tSplits, s.a)
+	case d4 == 0:
+		*tSplits = append(*tSplits, s.b)
+	default:
+		pt, _ := getSegmentIntersectPt(s.a, s.b, t.a, t.b)
+		*sSplits = append(*sSplits, pt)
+		*tSplits = append(*tSplits, pt)
+	}
+}
+
+// nearestSeg64 returns the index of the segment of segs nearest pt, if pt
+// lies beside it within rounding (sqrt 2) and not on any of them, or -1.
+func nearestSeg64(pt Point64, segs []edge64) int {
+	result, best := -1, 2.0
+	for i, s := range segs {
+		if pt == s.a || pt == s.b {
+			return -1
+		}
+		if pt.X < min(s.a.X, s.b.X)-1 || pt.X > max(s.a.X, s.b.X)+1 ||
+			pt.Y < min(s.a.Y, s.b.Y)-1 || pt.Y > max(s.a.Y, s.b.Y)+1 {
+			continue
+		}
+		dist := PerpendicDistFromLineSqr64(pt, s.a, s.b)
+		if dist == 0 && onSegment64(pt, s) {
+			return -1
+		}
+		if dist <= best && dotProduct64(s.a, pt, s.b) >= 0 {
+			result, best = i, dist
+		}
+	}
+	return result
+}
+
+// onSegment64 tells whether pt, collinear with s, lies within it.
+func onSegment64(pt Point64, s edge64) bool {
+	return pt.X >= min(s.a.X, s.b.X) && pt.X <= max(s.a.X, s.b.X) &&
+		pt.Y >= min(s.a.Y, s.b.Y) && pt.Y <= max(s.a.Y, s.b.Y)
+}
+
+// prune removes dangling edges, repeatedly, until every vertex left is on a
+// cycle or on a path between cycles.
+func (g *planarGraph64) prune() {
+	stack := make([]int, 0)
+	for v, nbrs := range g.adj {
+		if len(nbrs) == 1 {
+			stack = append(stack, v)
+		}
+	}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if len(g.adj[v]) != 1 {
+			continue
+		}
+		u := g.adj[v][0]
+		g.adj[v] = nil
+		g.adj[u] = slices.DeleteFunc(g.adj[u], func(w int) bool { return w == v })
+		if len(g.adj[u]) == 1 {
+			stack = append(stack, u)
+		}
+	}
+}
+
+// faces traces every face of the graph with the face on the left. Bounded
+// faces come out positively oriented (inner); the rings enclosing each
+// connected part of the graph come out negatively oriented (outer).
+func (g *planarGraph64) faces() (inner, outer Paths64) {
+	for v, nbrs := range g.adj {
+		o := g.pts[v]
+		slices.SortFunc(nbrs, func(i, j int) int {
+			return compareAngle64(Point64{g.pts[i].X - o.X, g.pts[i].Y - o.Y}, Point64{g.pts[j].X - o.X, g.pts[j].Y - o.Y})
+		})
+	}
+
+	inner, outer = make(Paths64, 0), make(Paths64, 0)
+	visited := make(map[[2]int]bool)
+	for u, nbrs := range g.adj {
+		for _, v := range nbrs {
+			if visited[[2]int{u, v}] {
+				continue
+			}
+			face := make(Path64, 0)
+			for a, b := u, v; !visited[[2]int{a, b}]; {
+				visited[[2]int{a, b}] = true
+				face = append(face, g.pts[a])
+				// turn as far right as possible, clockwise from b->a
+				next := g.adj[b]
+				k := slices.Index(next, a)
+				a, b = b, next[(k+len(next)-1)%len(next)]
+			}
+
+			switch area := Area64(face); {
+			case area > 0:
+				inner = append(inner, face)
+			case area < 0:
+				outer = append(outer, face)
+			}
+		}
+	}
+	return inner, outer
+}
+
+// inRegion tells whether the face, traced with its inside on the left, is
+// part of the region whose boundary, with the region on its left, was given
+// to newPlanarGraph64. A face runs along the boundary the same way as the
+// region does exactly when it's inside; one with no boundary edges is made
+// of lines clipped to the region, so it's inside too. Unlike testing a point
+// beside an edge, this holds where rounding has bent the boundary.
+func (g *planarGraph64) inRegion(face Path64) bool {
+	prev := face[len(face)-1]
+	for _, pt := range face {
+		if g.boundary[[2]Point64{prev, pt}] {
+			return true
+		}
+		if g.boundary[[2]Point64{pt, prev}] {
+			return false
+		}
+		prev = pt
+	}
+	return true
+}
+
+// compareAngle64 orders directions counter-clockwise from the positive X axis.
+func compareAngle64(p, q Point64) int {
+	half := func(pt Point64) int {
+		if pt.Y > 0 || (pt.Y == 0 && pt.X > 0) {
+			return 0
+		}
+		return 1
+	}
+	if c := cmp.Compare(half(p), half(q)); c != 0 {
+		return c
+	}
+	return -cmp.Compare(float64(p.X)*float64(q.Y)-float64(p.Y)*float64(q.X), 0)
+}
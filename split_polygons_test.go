@@ -0,0 +1,162 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitPolygonsByLines64(t *testing.T) {
+	var (
+		square   = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)}
+		withHole = goclipper2.Paths64{
+			goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+			goclipper2.MakePath64(40, 40, 40, 60, 60, 60, 60, 40),
+		}
+		// a U shape, which a horizontal line crosses twice
+		u = goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 70, 100, 70, 30, 30, 30, 30, 100, 0, 100)}
+	)
+
+	tests := []struct {
+		name     string
+		polygons goclipper2.Paths64
+		lines    goclipper2.Paths64
+		areas    []float64 // of the pieces, in any order
+		holes    int
+	}{
+		{
+			name:     "halves",
+			polygons: square,
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(50, -10, 50, 110)},
+			areas:    []float64{5000, 5000},
+		},
+		{
+			name:     "cross",
+			polygons: square,
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(50, -10, 50, 110), goclipper2.MakePath64(-10, 50, 110, 50)},
+			areas:    []float64{2500, 2500, 2500, 2500},
+		},
+		{
+			name:     "polyline",
+			polygons: square,
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(-10, 20, 50, 20, 50, 110)},
+			areas:    []float64{4000, 6000},
+		},
+		{
+			name:     "dangling",
+			polygons: square,
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(-10, 50, 50, 50)},
+			areas:    []float64{10000},
+		},
+		{
+			name:     "outside",
+			polygons: square,
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(200, -10, 200, 110)},
+			areas:    []float64{10000},
+		},
+		{
+			name:     "along an edge",
+			polygons: square,
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(-10, 0, 110, 0)},
+			areas:    []float64{10000},
+		},
+		{
+			name:     "hole kept",
+			polygons: withHole,
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(20, -10, 20, 110)},
+			areas:    []float64{2000, 8000 - 400},
+			holes:    1,
+		},
+		{
+			name:     "through the hole",
+			polygons: withHole,
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(50, -10, 50, 110)},
+			areas:    []float64{4800, 4800},
+		},
+		{
+			name:     "concave",
+			polygons: u,
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(-10, 60, 110, 60)},
+			areas:    []float64{1200, 1200, 4800},
+		},
+		{
+			// the cuts along the long sides are ordered past int64 products
+			name:     "long strip",
+			polygons: goclipper2.Paths64{goclipper2.MakePath64(0, 0, 4e9, 0, 4e9, 10, 0, 10)},
+			lines:    goclipper2.Paths64{goclipper2.MakePath64(2e9, -1, 2e9, 11), goclipper2.MakePath64(3e9, -1, 3e9, 11)},
+			areas:    []float64{2e10, 1e10, 1e10},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			pieces := goclipper2.SplitPolygonsByLines64(tt.polygons, tt.lines, goclipper2.NonZero)
+			areas, holes := make([]float64, 0), 0
+			for _, piece := range pieces {
+				area := 0.0
+				for j, path := range piece {
+					assert.Equal(t, j == 0, goclipper2.IsPositive64(path), "piece %v", piece)
+					area += goclipper2.Area64(path)
+				}
+				areas = append(areas, area)
+				holes += len(piece) - 1
+			}
+			assert.ElementsMatch(t, tt.areas, areas)
+			assert.Equal(t, tt.holes, holes)
+
+			// together the pieces cover the polygons exactly
+			all := goclipper2.Paths64{}
+			for _, piece := range pieces {
+				all = append(all, piece...)
+			}
+			xor := goclipper2.XorWithClipPaths64(all, tt.polygons, goclipper2.NonZero)
+			assert.Equal(t, 0, len(xor))
+		})
+	}
+}
+
+func TestSplitPolygonsByLinesOverlapping64(t *testing.T) {
+	// overlapping squares are cut as their union
+	polygons := goclipper2.Paths64{
+		goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+		goclipper2.MakePath64(50, 50, 150, 50, 150, 150, 50, 150),
+	}
+	lines := goclipper2.Paths64{goclipper2.MakePath64(-10, -10, 160, 160)}
+
+	pieces := goclipper2.SplitPolygonsByLines64(polygons, lines, goclipper2.NonZero)
+	assert.Equal(t, 2, len(pieces))
+	for _, piece := range pieces {
+		assert.InDelta(t, 8750, goclipper2.Area64(piece[0]), 1)
+	}
+}
+
+func TestSplitPolygonsByLinesSlanted64(t *testing.T) {
+	// the line crosses the long side off the integer grid, where the engine
+	// rounds its end to a point beside that side
+	polygons := goclipper2.Paths64{goclipper2.MakePath64(0, 0, 1000, 0, 0, 999)}
+	lines := goclipper2.Paths64{goclipper2.MakePath64(-10, 333, 1010, 333)}
+
+	pieces := goclipper2.SplitPolygonsByLines64(polygons, lines, goclipper2.NonZero)
+	assert.Equal(t, 2, len(pieces))
+	areas := make([]float64, 0)
+	for _, piece := range pieces {
+		assert.Equal(t, 1, len(piece))
+		areas = append(areas, math.Round(goclipper2.Area64(piece[0])/1000))
+	}
+	assert.ElementsMatch(t, []float64{278, 222}, areas)
+}
+
+func TestSplitPolygonsByLinesD(t *testing.T) {
+	polygons := goclipper2.PathsD{goclipper2.MakePathD(0, 0, 1, 0, 1, 1, 0, 1)}
+	lines := goclipper2.PathsD{goclipper2.MakePathD(0.25, -1, 0.25, 2)}
+
+	pieces := goclipper2.SplitPolygonsByLinesD(polygons, lines, goclipper2.NonZero)
+	areas := make([]float64, 0)
+	for _, piece := range pieces {
+		areas = append(areas, math.Round(goclipper2.AreaD(piece[0])*100)/100)
+	}
+	assert.ElementsMatch(t, []float64{0.25, 0.75}, areas)
+}
@@ -0,0 +1,504 @@
+package go_clipper2
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Skeleton64 is the straight skeleton of a region: the tracks left by the
+// vertices of its boundary as the boundary moves inwards at unit speed, every
+// edge staying parallel to itself (a mitered offset). Nodes holds the boundary
+// vertices, first, and the points where the moving boundary changes shape;
+// Times[i] is the offset distance at which the boundary reaches Nodes[i], zero
+// for the boundary vertices. Edges joins pairs of Nodes by index.
+type Skeleton64 struct {
+	Nodes  []Point64
+	Times  []float64
+	Edges  [][2]int
+	reflex []bool // Nodes that are reflex boundary vertices
+	verts  []*skelVertex
+}
+
+type SkeletonD struct {
+	Nodes    []PointD
+	Times    []float64
+	Edges    [][2]int
+	skeleton *Skeleton64
+	scale    float64
+}
+
+// StraightSkeleton64 returns the straight skeleton of the region paths fill
+// under fillRule. Nodes are rounded to integer coordinates.
+func StraightSkeleton64(paths Paths64, fillRule FillRule) *Skeleton64 {
+	b := &skelBuilder{}
+	rings := make(Paths64, 0)
+	for _, path := range UnionPaths64(paths, fillRule) {
+		if path = TrimCollinear64(StripDuplicates(path, true), false); len(path) > 2 {
+			rings = append(rings, path)
+		}
+	}
+	b.build(rings)
+	return b.skeleton()
+}
+
+// StraightSkeletonD is StraightSkeleton64 for PathsD, with coordinates rounded
+// to precisionV decimal places (2 by default).
+func StraightSkeletonD(paths PathsD, fillRule FillRule, precisionV ...int) *SkeletonD {
+	precision := 2
+	if len(precisionV) > 0 {
+		precision = precisionV[0]
+	}
+	checkPrecision(precision)
+	scale := math.Pow(10, float64(precision))
+
+	skeleton := StraightSkeleton64(ScalePathsDToPaths64(paths, scale), fillRule)
+	result := &SkeletonD{
+		Nodes:    ScalePath64ToPathD(skeleton.Nodes, 1/scale),
+		Times:    make([]float64, len(skeleton.Times)),
+		Edges:    skeleton.Edges,
+		skeleton: skeleton,
+		scale:    scale,
+	}
+	for i, t := range skeleton.Times {
+		result.Times[i] = t / scale
+	}
+	return result
+}
+
+// Offset returns the region shrunk by delta (delta >= 0): its boundary moved
+// inwards by delta, with mitered corners. This is what ClipperOffset returns
+// with a Miter join and an unlimited miter limit, except where reflex corners
+// of the shrinking boundary run into each other: the skeleton splits the
+// boundary there, where ClipperOffset keeps the corners going.
+func (s *Skeleton64) Offset(delta float64) Paths64 {
+	delta = max(delta, 0)
+	next := make(map[*skelVertex]*skelVertex)
+	for _, v := range s.verts {
+		if v.birth <= delta && delta < v.death {
+			k := len(v.links) - 1
+			for k > 0 && v.links[k].time > delta {
+				k--
+			}
+			next[v] = v.links[k].next
+		}
+	}
+
+	loops := make(Paths64, 0)
+	for _, v := range s.verts {
+		if _, ok := next[v]; !ok {
+			continue
+		}
+		loop := make(Path64, 0)
+		for u := v; u != nil; {
+			pt := u.at(delta)
+			loop = append(loop, NewFloatPoint64(pt.X, pt.Y))
+			w := next[u]
+			delete(next, u)
+			if w == v {
+				break
+			}
+			u = w
+		}
+		loops = append(loops, loop)
+	}
+	return UnionPaths64(loops, NonZero)
+}
+
+// MedialAxis returns the edges of the skeleton, as two point open paths, that
+// approximate the medial axis of the region: all of them except those running
+// into the region from its reflex vertices, where the medial axis curves away
+// from the boundary instead.
+func (s *Skeleton64) MedialAxis() Paths64 {
+	result := make(Paths64, 0, len(s.Edges))
+	for _, e := range s.Edges {
+		if !s.reflex[e[0]] && !s.reflex[e[1]] {
+			result = append(result, Path64{s.Nodes[e[0]], s.Nodes[e[1]]})
+		}
+	}
+	return result
+}
+
+func (s *SkeletonD) Offset(delta float64) PathsD {
+	return ScalePaths64ToPathsD(s.skeleton.Offset(delta*s.scale), 1/s.scale)
+}
+
+func (s *SkeletonD) MedialAxis() PathsD {
+	return ScalePaths64ToPathsD(s.skeleton.MedialAxis(), 1/s.scale)
+}
+
+// skelLine is the line of a boundary edge: through pt, in direction dir, with
+// the region on the side of its unit normal n.
+type skelLine struct {
+	pt, dir, n PointD
+}
+
+// skelVertex is a vertex of the moving boundary, between the edges on the
+// lines left and right. It moves in a straight line from pos, where it
+// appears at time birth, until it disappears at time death.
+type skelVertex struct {
+	pos          PointD
+	vel          PointD
+	birth, death float64
+	left, right  int
+	prev, next   *skelVertex
+	links        []skelLink // the values of next over time
+	node         int
+}
+
+type skelLink struct {
+	time float64
+	next *skelVertex
+}
+
+func (v *skelVertex) at(t float64) PointD {
+	return PointD{v.pos.X + v.vel.X*(t-v.birth), v.pos.Y + v.vel.Y*(t-v.birth)}
+}
+
+func (v *skelVertex) alive() bool {
+	return math.IsInf(v.death, 1)
+}
+
+// skelEvent is an edge event, when the edge between a and b (= a.next)
+// shrinks to nothing, or with b nil a split event, when the reflex vertex a
+// runs into an edge on the line line.
+type skelEvent struct {
+	time float64
+	a, b *skelVertex
+	line int
+}
+
+type skelQueue []skelEvent
+
+func (q skelQueue) Len() int { return len(q) }
+func (q skelQueue) Less(i, j int) bool {
+	if q[i].time != q[j].time {
+		return q[i].time < q[j].time
+	}
+	return q[i].b != nil && q[j].b == nil
+}
+func (q skelQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *skelQueue) Push(x any)   { *q = append(*q, x.(skelEvent)) }
+func (q *skelQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// skelBuilder runs the wavefront simulation: the boundary shrinks from event
+// to event, and every vertex that disappears leaves an edge in the skeleton
+// from the node it started at to the node it ended at.
+type skelBuilder struct {
+	lines  []skelLine
+	verts  []*skelVertex
+	onLine [][]*skelVertex // vertices by their right line
+	queue  skelQueue
+	nodes  []PointD
+	times  []float64
+	arcs   [][2]int
+	reflex []bool
+	tol    float64
+}
+
+func (b *skelBuilder) build(rings Paths64) {
+	bounds := pathsBounds64(rings)
+	b.tol = 1e-9 * max(1, float64(bounds.right-bounds.left), float64(bounds.bottom-bounds.top))
+
+	for _, ring := range rings {
+		first := len(b.lines)
+		for i, pt := range ring {
+			next := ring[(i+1)%len(ring)]
+			dx, dy := float64(next.X-pt.X), float64(next.Y-pt.Y)
+			l := math.Hypot(dx, dy)
+			b.lines = append(b.lines, skelLine{
+				pt:  PointD{float64(pt.X), float64(pt.Y)},
+				dir: PointD{dx / l, dy / l},
+				n:   PointD{-dy / l, dx / l},
+			})
+			b.onLine = append(b.onLine, nil)
+		}
+
+		ringVerts := make([]*skelVertex, len(ring))
+		for i, pt := range ring {
+			left := first + (i+len(ring)-1)%len(ring)
+			ringVerts[i] = b.newVertex(PointD{float64(pt.X), float64(pt.Y)}, 0, left, first+i)
+			ringVerts[i].node = b.addNode(ringVerts[i].pos, 0)
+			b.reflex = append(b.reflex, b.isReflex(ringVerts[i]))
+		}
+		for i, v := range ringVerts {
+			v.prev = ringVerts[(i+len(ring)-1)%len(ring)]
+			v.next = ringVerts[(i+1)%len(ring)]
+			v.links = append(v.links, skelLink{0, v.next})
+		}
+	}
+
+	for _, v := range b.verts {
+		b.pushEdgeEvent(v, 0)
+		b.pushSplitEvents(v, 0)
+	}
+	for b.queue.Len() > 0 {
+		ev := heap.Pop(&b.queue).(skelEvent)
+		if ev.b != nil {
+			b.edgeEvent(ev)
+		} else {
+			b.splitEvent(ev)
+		}
+	}
+}
+
+// skeleton rounds the nodes, merging those that round to the same point.
+func (b *skelBuilder) skeleton() *Skeleton64 {
+	s := &Skeleton64{
+		Nodes:  make([]Point64, 0, len(b.nodes)),
+		Times:  make([]float64, 0, len(b.nodes)),
+		Edges:  make([][2]int, 0, len(b.arcs)),
+		reflex: make([]bool, 0, len(b.nodes)),
+		verts:  b.verts,
+	}
+	index := make(map[Point64]int)
+	ids := make([]int, len(b.nodes))
+	for i, pt := range b.nodes {
+		pt64 := NewFloatPoint64(pt.X, pt.Y)
+		id, ok := index[pt64]
+		if !ok {
+			id = len(s.Nodes)
+			index[pt64] = id
+			s.Nodes = append(s.Nodes, pt64)
+			s.Times = append(s.Times, b.times[i])
+			s.reflex = append(s.reflex, false)
+		}
+		if i < len(b.reflex) && b.reflex[i] {
+			s.reflex[id] = true
+		}
+		ids[i] = id
+	}
+
+	seen := make(map[[2]int]bool)
+	for _, arc := range b.arcs {
+		u, v := ids[arc[0]], ids[arc[1]]
+		if u == v || seen[[2]int{u, v}] || seen[[2]int{v, u}] {
+			continue
+		}
+		seen[[2]int{u, v}] = true
+		s.Edges = append(s.Edges, [2]int{u, v})
+	}
+	return s
+}
+
+func (b *skelBuilder) addNode(pt PointD, t float64) int {
+	b.nodes = append(b.nodes, pt)
+	b.times = append(b.times, t)
+	return len(b.nodes) - 1
+}
+
+// newVertex adds a vertex appearing at pos at time t. Its velocity makes it
+// stay on both its lines as they move along their normals at unit speed; it
+// has none between lines facing each other, and settle folds it away.
+func (b *skelBuilder) newVertex(pos PointD, t float64, left, right int) *skelVertex {
+	n1, n2 := b.lines[left].n, b.lines[right].n
+	v := &skelVertex{pos: pos, birth: t, death: math.Inf(1), left: left, right: right}
+	switch det := n1.X*n2.Y - n1.Y*n2.X; {
+	case math.Abs(det) > 1e-12:
+		v.vel = PointD{(n2.Y - n1.Y) / det, (n1.X - n2.X) / det}
+	case n1.X*n2.X+n1.Y*n2.Y > 0:
+		v.vel = n1
+	}
+	b.verts = append(b.verts, v)
+	b.onLine[right] = append(b.onLine[right], v)
+	return v
+}
+
+func (b *skelBuilder) isReflex(v *skelVertex) bool {
+	d1, d2 := b.lines[v.left].dir, b.lines[v.right].dir
+	return d1.X*d2.Y-d1.Y*d2.X < -1e-12
+}
+
+// finish ends v at time t, at node, adding the edge it has traced.
+func (b *skelBuilder) finish(v *skelVertex, t float64, node int) {
+	v.death = t
+	if node != v.node {
+		b.arcs = append(b.arcs, [2]int{v.node, node})
+	}
+}
+
+func (b *skelBuilder) link(u, v *skelVertex, t float64) {
+	u.next, v.prev = v, u
+	u.links = append(u.links, skelLink{t, v})
+}
+
+func (b *skelBuilder) pushEdgeEvent(v *skelVertex, t float64) {
+	dir := b.lines[v.right].dir
+	p, q := v.at(t), v.next.at(t)
+	rate := (v.next.vel.X-v.vel.X)*dir.X + (v.next.vel.Y-v.vel.Y)*dir.Y
+	if rate >= -1e-12 {
+		return
+	}
+	length := max(0, (q.X-p.X)*dir.X+(q.Y-p.Y)*dir.Y)
+	heap.Push(&b.queue, skelEvent{time: t + length/-rate, a: v, b: v.next})
+}
+
+// pushSplitEvents adds the times at which v, if it's reflex, reaches the line
+// of each edge in front of it. Whether there's still an edge on that line
+// where it does is decided when the event comes up.
+func (b *skelBuilder) pushSplitEvents(v *skelVertex, t float64) {
+	if !b.isReflex(v) {
+		return
+	}
+	p := v.at(t)
+	for i, line := range b.lines {
+		if i == v.left || i == v.right {
+			continue
+		}
+		dist := (p.X-line.pt.X)*line.n.X + (p.Y-line.pt.Y)*line.n.Y - t
+		rate := v.vel.X*line.n.X + v.vel.Y*line.n.Y - 1
+		if dist > -b.tol && rate < -1e-12 {
+			heap.Push(&b.queue, skelEvent{time: t + max(0, dist)/-rate, a: v, line: i})
+		}
+	}
+}
+
+func (b *skelBuilder) edgeEvent(ev skelEvent) {
+	u, v, t := ev.a, ev.b, ev.time
+	if !u.alive() || !v.alive() || u.next != v {
+		return
+	}
+	p, q := u.at(t), v.at(t)
+	node := b.addNode(PointD{(p.X + q.X) / 2, (p.Y + q.Y) / 2}, t)
+	b.finish(u, t, node)
+	b.finish(v, t, node)
+
+	if v.next == u.prev {
+		// the last three vertices of a ring meet
+		b.finish(v.next, t, node)
+		return
+	}
+	w := b.newVertex(b.nodes[node], t, u.left, v.right)
+	w.node = node
+	b.link(u.prev, w, t)
+	b.link(w, v.next, t)
+	b.settle(w, t)
+}
+
+func (b *skelBuilder) splitEvent(ev skelEvent) {
+	v, t := ev.a, ev.time
+	if !v.alive() {
+		return
+	}
+	p := v.at(t)
+	dir := b.lines[ev.line].dir
+	var hit *skelVertex
+	var s, length float64
+	for _, a := range b.onLine[ev.line] {
+		if !a.alive() || a == v || a.next == v {
+			continue
+		}
+		pa, pb := a.at(t), a.next.at(t)
+		s = (p.X-pa.X)*dir.X + (p.Y-pa.Y)*dir.Y
+		length = (pb.X-pa.X)*dir.X + (pb.Y-pa.Y)*dir.Y
+		if s > -b.tol && s < length+b.tol {
+			hit = a
+			break
+		}
+	}
+	if hit == nil {
+		return
+	}
+
+	// v splits the edge in two, unless it meets the vertex at either end
+	// of it, and then the two vertices swap neighbours
+	left, right, prev, next := ev.line, ev.line, hit, hit.next
+	var x *skelVertex
+	switch {
+	case s < b.tol:
+		x = hit
+	case s > length-b.tol:
+		x = hit.next
+	}
+	if x != nil {
+		if x == v.prev || x == v.next {
+			return
+		}
+		left, right, prev, next = x.left, x.right, x.prev, x.next
+	}
+	node := b.addNode(p, t)
+	if x != nil {
+		b.finish(x, t, node)
+	}
+
+	vPrev, vNext := v.prev, v.next
+	b.finish(v, t, node)
+	v1 := b.newVertex(p, t, v.left, right)
+	v2 := b.newVertex(p, t, left, v.right)
+	v1.node, v2.node = node, node
+	b.link(vPrev, v1, t)
+	b.link(v1, next, t)
+	b.link(prev, v2, t)
+	b.link(v2, vNext, t)
+	b.settle(v1, t)
+	if v2.alive() {
+		b.settle(v2, t)
+	}
+}
+
+// settle schedules the events of the new vertex w, or if its ring is down to
+// two vertices, collapses it into an edge between them.
+func (b *skelBuilder) settle(w *skelVertex, t float64) {
+	if w.next.next == w {
+		u := w.next
+		node := b.addNode(u.at(t), t)
+		b.finish(u, t, node)
+		b.finish(w, t, node)
+		return
+	}
+	n1, n2 := b.lines[w.left].n, b.lines[w.right].n
+	if w.vel.X == 0 && w.vel.Y == 0 && n1.X*n2.X+n1.Y*n2.Y < 0 {
+		b.fold(w, t)
+		return
+	}
+	b.pushEdgeEvent(w.prev, t)
+	b.pushEdgeEvent(w, t)
+	b.pushSplitEvents(w, t)
+}
+
+// fold removes the vertex w between two lines facing each other, which meet
+// where w appears. The boundary doubles back on itself at w, and the doubled
+// part, up to the nearer of w's neighbours, vanishes at once.
+func (b *skelBuilder) fold(w *skelVertex, t float64) {
+	prev, next := w.prev, w.next
+	p, pp, pn := w.at(t), prev.at(t), next.at(t)
+	dp := math.Hypot(pp.X-p.X, pp.Y-p.Y)
+	dn := math.Hypot(pn.X-p.X, pn.Y-p.Y)
+
+	var u *skelVertex
+	switch {
+	case math.Abs(dp-dn) < b.tol:
+		node := b.addNode(pn, t)
+		b.finish(w, t, node)
+		b.finish(prev, t, node)
+		b.finish(next, t, node)
+		if prev.prev == next {
+			return
+		}
+		u = b.newVertex(pn, t, prev.left, next.right)
+		u.node = node
+		b.link(prev.prev, u, t)
+		b.link(u, next.next, t)
+	case dn < dp:
+		node := b.addNode(pn, t)
+		b.finish(w, t, node)
+		b.finish(next, t, node)
+		u = b.newVertex(pn, t, w.left, next.right)
+		u.node = node
+		b.link(prev, u, t)
+		b.link(u, next.next, t)
+	default:
+		node := b.addNode(pp, t)
+		b.finish(w, t, node)
+		b.finish(prev, t, node)
+		u = b.newVertex(pp, t, prev.left, w.right)
+		u.node = node
+		b.link(prev.prev, u, t)
+		b.link(u, next, t)
+	}
+	b.settle(u, t)
+}
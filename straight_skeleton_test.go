@@ -0,0 +1,140 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStraightSkeleton64(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths goclipper2.Paths64
+		inner goclipper2.Path64 // the nodes that aren't boundary vertices
+		times []float64
+		holes int
+	}{
+		{
+			name:  "square",
+			paths: goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100)},
+			inner: goclipper2.MakePath64(50, 50),
+			times: []float64{50},
+		},
+		{
+			name:  "rectangle",
+			paths: goclipper2.Paths64{goclipper2.MakePath64(0, 0, 200, 0, 200, 100, 0, 100)},
+			inner: goclipper2.MakePath64(50, 50, 150, 50),
+			times: []float64{50, 50},
+		},
+		{
+			name:  "l shape",
+			paths: goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 50, 50, 50, 50, 100, 0, 100)},
+			inner: goclipper2.MakePath64(25, 25, 75, 25, 25, 75),
+			times: []float64{25, 25, 25},
+		},
+		{
+			name: "square hole",
+			paths: goclipper2.Paths64{
+				goclipper2.MakePath64(0, 0, 100, 0, 100, 100, 0, 100),
+				goclipper2.MakePath64(30, 30, 30, 70, 70, 70, 70, 30),
+			},
+			inner: goclipper2.MakePath64(15, 15, 85, 15, 85, 85, 15, 85),
+			times: []float64{15, 15, 15, 15},
+			holes: 1,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			s := goclipper2.StraightSkeleton64(tt.paths, goclipper2.NonZero)
+
+			inner, times := goclipper2.Path64{}, []float64{}
+			for j, pt := range s.Nodes {
+				if s.Times[j] > 0 {
+					inner = append(inner, pt)
+					times = append(times, s.Times[j])
+				}
+			}
+			assert.ElementsMatch(t, tt.inner, inner)
+			assert.Equal(t, tt.times, times)
+			// the skeleton is connected, with a cycle around each hole
+			assert.Equal(t, len(s.Nodes)-1+tt.holes, len(s.Edges))
+		})
+	}
+}
+
+func TestStraightSkeletonOffset64(t *testing.T) {
+	tests := []struct {
+		name   string
+		paths  goclipper2.Paths64
+		deltas []float64
+	}{
+		{
+			name:   "l shape",
+			paths:  goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 50, 50, 50, 50, 100, 0, 100)},
+			deltas: []float64{0, 10, 20, 24},
+		},
+		{
+			name:   "dumbbell",
+			paths:  goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 40, 200, 40, 200, 0, 300, 0, 300, 100, 200, 100, 200, 60, 100, 60, 100, 100, 0, 100)},
+			deltas: []float64{10, 20, 30, 45},
+		},
+		{
+			name: "holes",
+			paths: goclipper2.Paths64{
+				goclipper2.MakePath64(0, 0, 400, 0, 400, 300, 0, 300),
+				goclipper2.MakePath64(50, 50, 50, 150, 150, 150, 150, 50),
+				goclipper2.MakePath64(250, 100, 250, 250, 350, 250, 350, 100),
+			},
+			deltas: []float64{10, 20, 40, 60},
+		},
+		{
+			name:   "star",
+			paths:  goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 80, 200, 0, 150, 120, 200, 240, 100, 160, 0, 240, 50, 120)},
+			deltas: []float64{10, 20, 30},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			s := goclipper2.StraightSkeleton64(tt.paths, goclipper2.NonZero)
+			for _, delta := range tt.deltas {
+				got := s.Offset(delta)
+				expect := goclipper2.InflatePaths64(tt.paths, -delta, goclipper2.Miter, goclipper2.Polygon, goclipper2.WithMitterLimit(1e6))
+				assert.NotEmpty(t, got)
+				// the paths differ only by the rounding of their vertices
+				xor := goclipper2.XorWithClipPaths64(got, expect, goclipper2.NonZero)
+				assert.Less(t, goclipper2.AreaPaths64(xor), 100.0, "delta %v", delta)
+			}
+			assert.Empty(t, s.Offset(1000))
+		})
+	}
+}
+
+func TestStraightSkeletonMedialAxis64(t *testing.T) {
+	// the bisector from the reflex corner of an l shape isn't on its medial
+	// axis, everything else is
+	s := goclipper2.StraightSkeleton64(goclipper2.Paths64{goclipper2.MakePath64(0, 0, 100, 0, 100, 50, 50, 50, 50, 100, 0, 100)}, goclipper2.NonZero)
+	axis := s.MedialAxis()
+	assert.Equal(t, len(s.Edges)-1, len(axis))
+	for _, edge := range axis {
+		assert.NotContains(t, edge, goclipper2.Point64{X: 50, Y: 50})
+	}
+}
+
+func TestStraightSkeletonD(t *testing.T) {
+	s := goclipper2.StraightSkeletonD(goclipper2.PathsD{goclipper2.MakePathD(0, 0, 2, 0, 2, 1, 0, 1)}, goclipper2.NonZero)
+
+	inner := goclipper2.PathD{}
+	for j, pt := range s.Nodes {
+		if s.Times[j] > 0 {
+			inner = append(inner, pt)
+			assert.InDelta(t, 0.5, s.Times[j], 1e-9)
+		}
+	}
+	assert.ElementsMatch(t, goclipper2.PathD{{X: 0.5, Y: 0.5}, {X: 1.5, Y: 0.5}}, inner)
+	assert.InDelta(t, 1.2*0.2, goclipper2.AreaPathsD(s.Offset(0.4)), 1e-9)
+	assert.Equal(t, 5, len(s.MedialAxis()))
+}
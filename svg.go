@@ -0,0 +1,282 @@
+package go_clipper2
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// ParseSvgPathD converts SVG path data (the "d" attribute) into PathsD,
+// flattening curves and arcs with the given tolerance.
+func ParseSvgPathD(d string, tolerance float64) (PathsD, error) {
+	b := NewPathBuilderD(tolerance)
+	if err := b.AppendSvgPath(d); err != nil {
+		return nil, err
+	}
+	return b.Paths(), nil
+}
+
+// AppendSvgPath parses SVG path data and appends its commands to the builder.
+// Both absolute (upper case) and relative (lower case) commands are supported.
+func (b *PathBuilderD) AppendSvgPath(d string) error {
+	s := &svgScanner{data: d}
+
+	var (
+		cmd         byte
+		lastCtrl    PointD
+		lastWasCub  bool
+		lastWasQuad bool
+	)
+
+	for {
+		s.skipSeparators()
+		if s.eof() {
+			return nil
+		}
+
+		if c := s.peek(); isSvgCommand(c) {
+			if cmd == 0 && c != 'M' && c != 'm' {
+				return s.errorf("path data must start with a moveto")
+			}
+			cmd = c
+			s.pos++
+		} else if cmd == 0 {
+			return s.errorf("expected command")
+		} else if cmd == 'Z' || cmd == 'z' {
+			return s.errorf("unexpected number after close")
+		}
+
+		cur := b.CurrentPoint()
+		rel := cmd >= 'a'
+		offset := func(x, y float64) (float64, float64) {
+			if rel {
+				return cur.X + x, cur.Y + y
+			}
+			return x, y
+		}
+
+		isCub, isQuad := false, false
+		switch cmd {
+		case 'M', 'm':
+			v, err := s.numbers(2)
+			if err != nil {
+				return err
+			}
+			x, y := offset(v[0], v[1])
+			b.MoveTo(x, y)
+			// subsequent coordinate pairs are implicit line-to commands
+			if rel {
+				cmd = 'l'
+			} else {
+				cmd = 'L'
+			}
+		case 'L', 'l':
+			v, err := s.numbers(2)
+			if err != nil {
+				return err
+			}
+			b.LineTo(offset(v[0], v[1]))
+		case 'H', 'h':
+			v, err := s.numbers(1)
+			if err != nil {
+				return err
+			}
+			x := v[0]
+			if rel {
+				x += cur.X
+			}
+			b.LineTo(x, cur.Y)
+		case 'V', 'v':
+			v, err := s.numbers(1)
+			if err != nil {
+				return err
+			}
+			y := v[0]
+			if rel {
+				y += cur.Y
+			}
+			b.LineTo(cur.X, y)
+		case 'C', 'c':
+			v, err := s.numbers(6)
+			if err != nil {
+				return err
+			}
+			x1, y1 := offset(v[0], v[1])
+			x2, y2 := offset(v[2], v[3])
+			x, y := offset(v[4], v[5])
+			b.CurveTo(x1, y1, x2, y2, x, y)
+			lastCtrl, isCub = PointD{X: x2, Y: y2}, true
+		case 'S', 's':
+			v, err := s.numbers(4)
+			if err != nil {
+				return err
+			}
+			x1, y1 := cur.X, cur.Y
+			if lastWasCub {
+				x1, y1 = 2*cur.X-lastCtrl.X, 2*cur.Y-lastCtrl.Y
+			}
+			x2, y2 := offset(v[0], v[1])
+			x, y := offset(v[2], v[3])
+			b.CurveTo(x1, y1, x2, y2, x, y)
+			lastCtrl, isCub = PointD{X: x2, Y: y2}, true
+		case 'Q', 'q':
+			v, err := s.numbers(4)
+			if err != nil {
+				return err
+			}
+			x1, y1 := offset(v[0], v[1])
+			x, y := offset(v[2], v[3])
+			b.QuadTo(x1, y1, x, y)
+			lastCtrl, isQuad = PointD{X: x1, Y: y1}, true
+		case 'T', 't':
+			v, err := s.numbers(2)
+			if err != nil {
+				return err
+			}
+			x1, y1 := cur.X, cur.Y
+			if lastWasQuad {
+				x1, y1 = 2*cur.X-lastCtrl.X, 2*cur.Y-lastCtrl.Y
+			}
+			x, y := offset(v[0], v[1])
+			b.QuadTo(x1, y1, x, y)
+			lastCtrl, isQuad = PointD{X: x1, Y: y1}, true
+		case 'A', 'a':
+			v, err := s.numbers(3)
+			if err != nil {
+				return err
+			}
+			largeArc, err := s.flag()
+			if err != nil {
+				return err
+			}
+			sweep, err := s.flag()
+			if err != nil {
+				return err
+			}
+			end, err := s.numbers(2)
+			if err != nil {
+				return err
+			}
+			x, y := offset(end[0], end[1])
+			b.ArcTo(v[0], v[1], v[2]*math.Pi/180, largeArc, sweep, x, y)
+		case 'Z', 'z':
+			b.Close()
+		}
+
+		lastWasCub, lastWasQuad = isCub, isQuad
+	}
+}
+
+func isSvgCommand(c byte) bool {
+	switch c {
+	case 'M', 'm', 'L', 'l', 'H', 'h', 'V', 'v', 'C', 'c',
+		'S', 's', 'Q', 'q', 'T', 't', 'A', 'a', 'Z', 'z':
+		return true
+	}
+	return false
+}
+
+type svgScanner struct {
+	data string
+	pos  int
+}
+
+func (s *svgScanner) eof() bool {
+	return s.pos >= len(s.data)
+}
+
+func (s *svgScanner) peek() byte {
+	return s.data[s.pos]
+}
+
+func (s *svgScanner) errorf(msg string) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalidSvgPath, msg, s.pos)
+}
+
+func (s *svgScanner) skipSeparators() {
+	for !s.eof() {
+		switch s.peek() {
+		case ' ', '\t', '\n', '\r', '\f', ',':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *svgScanner) numbers(n int) ([]float64, error) {
+	result := make([]float64, n)
+	for i := range result {
+		v, err := s.number()
+		if err != nil {
+			return nil, err
+		}
+		result[i] = v
+	}
+	return result, nil
+}
+
+func (s *svgScanner) number() (float64, error) {
+	s.skipSeparators()
+	start := s.pos
+	if !s.eof() && (s.peek() == '+' || s.peek() == '-') {
+		s.pos++
+	}
+
+	digits, dot := 0, false
+	for !s.eof() {
+		c := s.peek()
+		if c >= '0' && c <= '9' {
+			digits++
+		} else if c == '.' && !dot {
+			dot = true
+		} else {
+			break
+		}
+		s.pos++
+	}
+	if digits == 0 {
+		s.pos = start
+		return 0, s.errorf("expected number")
+	}
+
+	if !s.eof() && (s.peek() == 'e' || s.peek() == 'E') {
+		exp := s.pos
+		s.pos++
+		if !s.eof() && (s.peek() == '+' || s.peek() == '-') {
+			s.pos++
+		}
+		expDigits := 0
+		for !s.eof() && s.peek() >= '0' && s.peek() <= '9' {
+			s.pos++
+			expDigits++
+		}
+		if expDigits == 0 {
+			s.pos = exp
+		}
+	}
+
+	v, err := strconv.ParseFloat(s.data[start:s.pos], 64)
+	if err != nil {
+		s.pos = start
+		return 0, s.errorf("invalid number")
+	}
+	return v, nil
+}
+
+// flag reads a single arc flag; flags may be written without separators.
+func (s *svgScanner) flag() (bool, error) {
+	s.skipSeparators()
+	if s.eof() {
+		return false, s.errorf("expected flag")
+	}
+	switch s.peek() {
+	case '0':
+		s.pos++
+		return false, nil
+	case '1':
+		s.pos++
+		return true, nil
+	}
+	return false, s.errorf("expected flag")
+}
@@ -0,0 +1,98 @@
+package go_clipper2_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSvgPathD(t *testing.T) {
+	tests := []struct {
+		name   string
+		d      string
+		count  int
+		area   float64
+		margin float64
+	}{
+		{
+			name:  "absolute lines",
+			d:     "M0 0 L100 0 L100 100 L0 100 Z",
+			count: 1,
+			area:  10000,
+		},
+		{
+			name:  "relative lines with implicit line-to",
+			d:     "m10,10 90,0 0,90 -90,0z",
+			count: 1,
+			area:  8100,
+		},
+		{
+			name:  "horizontal and vertical",
+			d:     "M0 0H50V20h-50v-20z",
+			count: 1,
+			area:  1000,
+		},
+		{
+			name:   "arc circle with compact flags",
+			d:      "M-50 0a50 50 0 1 0 100 0a50 50 0 1 0-100 0z",
+			count:  1,
+			area:   math.Pi * 50 * 50,
+			margin: 25,
+		},
+		{
+			name:   "smooth quadratic",
+			d:      "M0 0Q50-50 100 0T200 0L200 100L0 100z",
+			count:  1,
+			area:   200 * 100,
+			margin: 10,
+		},
+		{
+			name:   "cubic with smooth continuation",
+			d:      "M0,0 C0,-55.23 44.77,-100 100,-100 S200,-55.23 200,0 Z",
+			count:  1,
+			area:   math.Pi * 100 * 100 / 2,
+			margin: 100,
+		},
+		{
+			name:  "two sub-paths and exponents",
+			d:     "M0 0h1e1v1E1h-10z M20 20h.5e1v5h-5z",
+			count: 2,
+			area:  125,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			paths, err := goclipper2.ParseSvgPathD(tt.d, 0.1)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.count, len(paths))
+
+			area := 0.0
+			for _, p := range paths {
+				area += math.Abs(goclipper2.AreaD(p))
+			}
+			assert.InDelta(t, tt.area, area, tt.margin+1e-9)
+		})
+	}
+}
+
+func TestParseSvgPathDErrors(t *testing.T) {
+	for _, d := range []string{"L10 10", "M10", "M0 0 A10 10 0 2 0 10 10", "M0 0 Z 10"} {
+		_, err := goclipper2.ParseSvgPathD(d, 0)
+		assert.True(t, errors.Is(err, goclipper2.ErrInvalidSvgPath), d)
+	}
+}
+
+func TestSvgIconStroke(t *testing.T) {
+	b := goclipper2.NewPathBuilderD(0.05)
+	err := b.AppendSvgPath("M10 10 C20 0 40 0 50 10")
+	assert.NoError(t, err)
+
+	stroke := goclipper2.InflatePathsD(b.OpenPaths(), 2, goclipper2.Round, goclipper2.RoundET)
+	assert.Equal(t, 1, len(stroke))
+	assert.Greater(t, goclipper2.AreaPathsD(stroke), 0.0)
+}
@@ -0,0 +1,161 @@
+package go_clipper2
+
+import (
+	"math"
+	"slices"
+)
+
+// OffsetNode64 is a region produced by ConcentricOffsets64: an outer contour
+// followed by its holes. Children are the regions of the next inward offset
+// that lie inside this region.
+type OffsetNode64 struct {
+	Contour  Paths64
+	Level    int
+	Parent   *OffsetNode64
+	Children []*OffsetNode64
+}
+
+type OffsetNodeD struct {
+	Contour  PathsD
+	Level    int
+	Parent   *OffsetNodeD
+	Children []*OffsetNodeD
+}
+
+// ConcentricOffsets64 repeatedly offsets the closed paths inwards by step until
+// nothing is left. The returned root node has Level 0 and no contour, its
+// children are the regions of the first offset (Level 1), and so on.
+func ConcentricOffsets64(paths Paths64, step float64, joinType JoinType, opts ...InflateOption) *OffsetNode64 {
+	cfg := &inflateConfig{
+		miterLimit:   2.0,
+		arcTolerance: 0,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	root := &OffsetNode64{Children: make([]*OffsetNode64, 0)}
+	step = math.Abs(step)
+	if step < 0.5 || len(paths) == 0 {
+		return root
+	}
+
+	// the groups are built once; every level is offset from the original
+	// paths so rounding errors don't accumulate between iterations
+	co := NewClipperOffset(cfg.miterLimit, cfg.arcTolerance, false, false)
+	co.AddPaths(paths, joinType, Polygon)
+
+	prev := []*OffsetNode64{root}
+	for level := 1; ; level++ {
+		solution := make(Paths64, 0)
+		co.Execute64(-step*float64(level), &solution)
+		if len(solution) == 0 {
+			break
+		}
+
+		regions := regionsFromPolyTree64(BooleanOpPolyTree64(Union, solution, nil, NonZero))
+		if len(regions) == 0 {
+			break
+		}
+
+		curr := make([]*OffsetNode64, 0, len(regions))
+		for _, region := range regions {
+			parent := root
+			if level > 1 {
+				parent = findContainingRegion64(prev, region[0][0])
+			}
+			node := &OffsetNode64{
+				Contour:  region,
+				Level:    level,
+				Parent:   parent,
+				Children: make([]*OffsetNode64, 0),
+			}
+			parent.Children = append(parent.Children, node)
+			curr = append(curr, node)
+		}
+		prev = curr
+	}
+
+	return root
+}
+
+func ConcentricOffsetsD(paths PathsD, step float64, joinType JoinType, opts ...InflateOption) *OffsetNodeD {
+	cfg := &inflateConfig{
+		arcTolerance: 0,
+		precision:    2,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// panic if wrong precision
+	checkPrecision(cfg.precision)
+
+	scale := math.Pow(10, float64(cfg.precision))
+	// a copy, so the caller's opts aren't written to through spare capacity
+	opts = append(slices.Clone(opts), WithArcTolerance(cfg.arcTolerance*scale))
+	tree := ConcentricOffsets64(ScalePathsDToPaths64(paths, scale), step*scale, joinType, opts...)
+
+	return offsetNode64ToD(tree, nil, 1/scale)
+}
+
+func offsetNode64ToD(node *OffsetNode64, parent *OffsetNodeD, invScale float64) *OffsetNodeD {
+	result := &OffsetNodeD{
+		Level:    node.Level,
+		Parent:   parent,
+		Children: make([]*OffsetNodeD, 0, len(node.Children)),
+	}
+	if node.Contour != nil {
+		result.Contour = ScalePaths64ToPathsD(node.Contour, invScale)
+	}
+	for _, child := range node.Children {
+		result.Children = append(result.Children, offsetNode64ToD(child, result, invScale))
+	}
+	return result
+}
+
+// regionsFromPolyTree64 splits a polytree into outer contours paired with
+// their direct holes. Islands inside holes become regions of their own.
+func regionsFromPolyTree64(polytree *PolyTree64) []Paths64 {
+	result := make([]Paths64, 0)
+
+	var walk func(pp *PolyPathBase)
+	walk = func(pp *PolyPathBase) {
+		for _, outer := range pp.GetChildren() {
+			region := Paths64{outer.Polygon()}
+			for _, hole := range outer.GetChildren() {
+				region = append(region, hole.Polygon())
+				walk(hole)
+			}
+			result = append(result, region)
+		}
+	}
+	walk(polytree.PolyPathBase)
+
+	return result
+}
+
+func findContainingRegion64(nodes []*OffsetNode64, pt Point64) *OffsetNode64 {
+	for _, node := range nodes {
+		if regionContainsPoint64(node.Contour, pt) {
+			return node
+		}
+	}
+	// an inward offset always lies inside the previous level, this is only
+	// reachable through rounding at the very boundary
+	return nodes[0]
+}
+
+func regionContainsPoint64(region Paths64, pt Point64) bool {
+	if len(region) == 0 || PointInPolygon(pt, region[0]) == IsOutside {
+		return false
+	}
+	for _, hole := range region[1:] {
+		if PointInPolygon(pt, hole) == IsInside {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package go_clipper2_test
+
+import (
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcentricOffsets64(t *testing.T) {
+	// two 100x100 squares joined by a 40 unit wide bridge
+	dumbbell := goclipper2.Paths64{
+		goclipper2.MakePath64(0, 0, 100, 0, 100, 30, 200, 30, 200, 0, 300, 0, 300, 100, 200, 100, 200, 70, 100, 70, 100, 100, 0, 100),
+	}
+
+	root := goclipper2.ConcentricOffsets64(dumbbell, 10, goclipper2.Miter)
+	assert.Equal(t, 0, root.Level)
+	assert.Equal(t, 1, len(root.Children))
+
+	first := root.Children[0]
+	assert.Equal(t, 1, first.Level)
+	assert.Equal(t, 1, len(first.Contour))
+	assert.Equal(t, root, first.Parent)
+
+	// the bridge disappears at the second step
+	assert.Equal(t, 2, len(first.Children))
+	for _, lobe := range first.Children {
+		assert.Equal(t, 2, lobe.Level)
+		assert.Equal(t, first, lobe.Parent)
+
+		depth, node := 2, lobe
+		for len(node.Children) > 0 {
+			assert.Equal(t, 1, len(node.Children))
+			node = node.Children[0]
+			depth++
+			assert.Equal(t, depth, node.Level)
+		}
+		assert.Equal(t, 4, depth)
+		assert.Equal(t, 20.0*20.0, goclipper2.AreaPaths64(node.Contour))
+	}
+}
+
+func TestConcentricOffsetsWithHoleD(t *testing.T) {
+	ring := goclipper2.PathsD{
+		goclipper2.MakePathD(0, 0, 100, 0, 100, 100, 0, 100),
+		goclipper2.MakePathD(35, 35, 35, 65, 65, 65, 65, 35),
+	}
+
+	root := goclipper2.ConcentricOffsetsD(ring, 5, goclipper2.Miter)
+	assert.Equal(t, 1, len(root.Children))
+
+	level, node := 0, root
+	for len(node.Children) > 0 {
+		node = node.Children[0]
+		level++
+		assert.Equal(t, 2, len(node.Contour))
+		outer := 100 - 10*float64(level)
+		hole := 30 + 10*float64(level)
+		assert.InDelta(t, outer*outer-hole*hole, goclipper2.AreaPathsD(node.Contour), 1e-9)
+	}
+	assert.Equal(t, 3, level)
+
+	// the caller's options aren't written to through their spare capacity
+	opts := make([]goclipper2.InflateOption, 1, 2)
+	opts[0] = goclipper2.WithArcTolerance(0.1)
+	goclipper2.ConcentricOffsetsD(ring, 5, goclipper2.Round, opts...)
+	assert.Nil(t, opts[:2][1])
+}
+
+func TestConcentricOffsetsEmpty(t *testing.T) {
+	root := goclipper2.ConcentricOffsets64(goclipper2.Paths64{goclipper2.MakePath64(0, 0, 10, 0, 10, 10, 0, 10)}, 20, goclipper2.Round)
+	assert.Equal(t, 0, len(root.Children))
+}
@@ -0,0 +1,212 @@
+package go_clipper2
+
+import (
+	"math"
+	"sort"
+)
+
+// VoronoiD is the Voronoi diagram of a set of sites clipped to a region.
+// Cells[i] is the part of the region closer to Sites[i] than to any other
+// site, empty when the site repeats an earlier one or its cell falls outside
+// the region. Triangles is the Delaunay triangulation the cells were built
+// from, each a positive triangle of indices into Sites.
+type VoronoiD struct {
+	Sites     PathD
+	Cells     []PathsD
+	Triangles [][3]int
+}
+
+// VoronoiRectD returns the Voronoi diagram of sites clipped to rect.
+func VoronoiRectD(sites PathD, rect RectD, precisionV ...int) *VoronoiD {
+	return newVoronoiD(sites, rect.AsPath(), func(cell PathD) PathsD {
+		return RectClipPathsD(rect, PathsD{cell}, precisionV...)
+	})
+}
+
+// VoronoiPathsD returns the Voronoi diagram of sites clipped to the region
+// clip encloses under fillRule, which may be concave or have holes, so a
+// cell may come out in several pieces.
+func VoronoiPathsD(sites PathD, clip PathsD, fillRule FillRule, precisionV ...int) *VoronoiD {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, path := range clip {
+		for _, pt := range path {
+			minX, minY = math.Min(minX, pt.X), math.Min(minY, pt.Y)
+			maxX, maxY = math.Max(maxX, pt.X), math.Max(maxY, pt.Y)
+		}
+	}
+	var bounds PathD
+	if !math.IsInf(minX, 1) {
+		bounds = PathD{{X: minX, Y: minY}, {X: maxX, Y: minY}, {X: maxX, Y: maxY}, {X: minX, Y: maxY}}
+	}
+	return newVoronoiD(sites, bounds, func(cell PathD) PathsD {
+		return IntersectWithClipPathsD(PathsD{cell}, clip, fillRule, precisionV...)
+	})
+}
+
+// DelaunayTriangulationD returns the Delaunay triangulation of sites, each
+// triangle a positive triple of indices into sites. Repeated sites are used
+// once, and collinear ones give no triangles.
+func DelaunayTriangulationD(sites PathD) [][3]int {
+	triangles, _ := delaunayD(sites)
+	return triangles
+}
+
+// newVoronoiD cuts each site's cell out of bounds, a convex path around the
+// region, by the bisectors with its Delaunay neighbours and then hands it to
+// clip for the exact region.
+func newVoronoiD(sites PathD, bounds PathD, clip func(PathD) PathsD) *VoronoiD {
+	triangles, neighbours := delaunayD(sites)
+	result := &VoronoiD{
+		Sites:     sites,
+		Cells:     make([]PathsD, len(sites)),
+		Triangles: triangles,
+	}
+
+	for i, site := range sites {
+		result.Cells[i] = PathsD{}
+		if neighbours[i] == nil {
+			continue
+		}
+		cell := bounds
+		for _, j := range neighbours[i] {
+			if len(cell) < 3 {
+				break
+			}
+			// the bisector, directed to keep site on its left
+			other := sites[j]
+			mid := PointD{X: (site.X + other.X) / 2, Y: (site.Y + other.Y) / 2}
+			cell = clipHalfPlaneD(cell, mid, PointD{X: mid.X - (other.Y - site.Y), Y: mid.Y + (other.X - site.X)})
+		}
+		if len(cell) >= 3 {
+			result.Cells[i] = clip(cell)
+		}
+	}
+	return result
+}
+
+// delaunayTriangle is a triangle of the Bowyer-Watson triangulation with its
+// circumcircle.
+type delaunayTriangle struct {
+	a, b, c int
+	cx, cy  float64
+	r2      float64
+}
+
+// delaunayD triangulates sites by Bowyer-Watson, inserting them by x so
+// that the triangles whose circumcircles lie left of the next site can be
+// set aside (Bourke). It also returns the neighbours of every site, nil for a
+// repeat, taken from all the triangles including those on the temporary
+// super triangle, so that collinear sites still get the sites either side.
+func delaunayD(sites PathD) ([][3]int, [][]int) {
+	neighbours := make([][]int, len(sites))
+	order := make([]int, len(sites))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := sites[order[i]], sites[order[j]]
+		return a.X < b.X || (a.X == b.X && a.Y < b.Y)
+	})
+
+	// unique holds the index into sites of each distinct site
+	unique := make([]int, 0, len(order))
+	for _, i := range order {
+		if len(unique) > 0 && sites[unique[len(unique)-1]] == sites[i] {
+			continue
+		}
+		unique = append(unique, i)
+		neighbours[i] = []int{}
+	}
+	if len(unique) < 2 {
+		return [][3]int{}, neighbours
+	}
+
+	// the sites are moved into a unit box round the origin to keep the
+	// circumcircles of the super triangle within float precision
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, i := range unique {
+		minX, minY = math.Min(minX, sites[i].X), math.Min(minY, sites[i].Y)
+		maxX, maxY = math.Max(maxX, sites[i].X), math.Max(maxY, sites[i].Y)
+	}
+	size := math.Max(maxX-minX, maxY-minY)
+	midX, midY := (minX+maxX)/2, (minY+maxY)/2
+
+	cnt := len(unique)
+	pts := make(PathD, cnt+3)
+	for k, i := range unique {
+		pts[k] = PointD{X: (sites[i].X - midX) / size, Y: (sites[i].Y - midY) / size}
+	}
+	const m = 1000.0
+	pts[cnt] = PointD{X: -m, Y: -m}
+	pts[cnt+1] = PointD{X: m, Y: -m}
+	pts[cnt+2] = PointD{X: 0, Y: m}
+
+	open := []delaunayTriangle{newDelaunayTriangle(pts, cnt, cnt+1, cnt+2)}
+	var closed []delaunayTriangle
+	for p := 0; p < cnt; p++ {
+		pt := pts[p]
+		// the edges of the cavity, each keyed by its reverse so that the
+		// ones two removed triangles share cancel out
+		edges := make(map[[2]int]bool)
+		var edgeOrder [][2]int
+		kept := open[:0]
+		for _, t := range open {
+			dx, dy := pt.X-t.cx, pt.Y-t.cy
+			if dx > 0 && dx*dx > t.r2 {
+				closed = append(closed, t)
+				continue
+			}
+			if dx*dx+dy*dy >= t.r2 {
+				kept = append(kept, t)
+				continue
+			}
+			for _, e := range [][2]int{{t.a, t.b}, {t.b, t.c}, {t.c, t.a}} {
+				if rev := [2]int{e[1], e[0]}; edges[rev] {
+					delete(edges, rev)
+				} else {
+					edges[e] = true
+					edgeOrder = append(edgeOrder, e)
+				}
+			}
+		}
+		open = kept
+		for _, e := range edgeOrder {
+			if edges[e] {
+				open = append(open, newDelaunayTriangle(pts, e[0], e[1], p))
+			}
+		}
+	}
+	closed = append(closed, open...)
+
+	triangles := make([][3]int, 0, len(closed))
+	for _, t := range closed {
+		for _, e := range [][2]int{{t.a, t.b}, {t.b, t.c}, {t.c, t.a}} {
+			if e[0] < cnt && e[1] < cnt {
+				i := unique[e[0]]
+				neighbours[i] = append(neighbours[i], unique[e[1]])
+			}
+		}
+		if t.a < cnt && t.b < cnt && t.c < cnt && !math.IsInf(t.r2, 1) {
+			triangles = append(triangles, [3]int{unique[t.a], unique[t.b], unique[t.c]})
+		}
+	}
+	return triangles, neighbours
+}
+
+// newDelaunayTriangle returns the triangle a, b, c of pts, which must be
+// positive, or else degenerate with an infinite circumcircle.
+func newDelaunayTriangle(pts PathD, a, b, c int) delaunayTriangle {
+	pa, pb, pc := pts[a], pts[b], pts[c]
+	bx, by := pb.X-pa.X, pb.Y-pa.Y
+	cx, cy := pc.X-pa.X, pc.Y-pa.Y
+	d := 2 * (bx*cy - by*cx)
+	if d <= 0 {
+		return delaunayTriangle{a: a, b: b, c: c, cx: pa.X, cy: pa.Y, r2: math.Inf(1)}
+	}
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	ux := (cy*b2 - by*c2) / d
+	uy := (bx*c2 - cx*b2) / d
+	return delaunayTriangle{a: a, b: b, c: c, cx: pa.X + ux, cy: pa.Y + uy, r2: ux*ux + uy*uy}
+}
@@ -0,0 +1,133 @@
+package go_clipper2_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	goclipper2 "github.com/bolom009/go-clipper2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVoronoiRectD(t *testing.T) {
+	rect := goclipper2.NewRectD(0, 0, 300, 300)
+	var grid goclipper2.PathD
+	for x := 50.0; x < 300; x += 100 {
+		for y := 50.0; y < 300; y += 100 {
+			grid = append(grid, goclipper2.PointD{X: x, Y: y})
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(7))
+	var scattered goclipper2.PathD
+	for i := 0; i < 50; i++ {
+		scattered = append(scattered, goclipper2.PointD{X: rnd.Float64() * 300, Y: rnd.Float64() * 300})
+	}
+
+	tests := []struct {
+		name      string
+		sites     goclipper2.PathD
+		triangles int
+	}{
+		{name: "grid", sites: grid, triangles: 8},
+		{name: "scattered", sites: scattered},
+		{name: "single", sites: goclipper2.MakePathD(10, 20)},
+		{name: "pair", sites: goclipper2.MakePathD(100, 100, 200, 200)},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tt.name), func(t *testing.T) {
+			v := goclipper2.VoronoiRectD(tt.sites, rect)
+			assert.Equal(t, len(tt.sites), len(v.Cells))
+			if tt.triangles > 0 {
+				assert.Equal(t, tt.triangles, len(v.Triangles))
+			}
+
+			total := 0.0
+			for j, cell := range v.Cells {
+				assert.Equal(t, 1, len(cell))
+				assert.Equal(t, goclipper2.IsInside, goclipper2.PointInPathsD(tt.sites[j], cell, goclipper2.NonZero))
+				total += goclipper2.AreaPathsD(cell)
+			}
+			assert.InDelta(t, 300*300, total, 1)
+		})
+	}
+
+	// the grid's cells are the squares round its sites
+	for _, cell := range goclipper2.VoronoiRectD(grid, rect).Cells {
+		assert.InDelta(t, 100*100, goclipper2.AreaPathsD(cell), 1e-6)
+	}
+}
+
+func TestVoronoiPathsD(t *testing.T) {
+	// a square with a square hole in the middle
+	clip := goclipper2.PathsD{
+		goclipper2.MakePathD(0, 0, 300, 0, 300, 300, 0, 300),
+		goclipper2.MakePathD(100, 100, 100, 200, 200, 200, 200, 100),
+	}
+	sites := goclipper2.MakePathD(50, 150, 250, 150, 150, 50, 150, 250, 250, 150)
+
+	v := goclipper2.VoronoiPathsD(sites, clip, goclipper2.NonZero)
+	assert.Equal(t, len(sites), len(v.Cells))
+	// the repeated site gets nothing
+	assert.Equal(t, 0, len(v.Cells[4]))
+
+	total := 0.0
+	for j, cell := range v.Cells[:4] {
+		assert.Equal(t, 1, len(cell))
+		assert.Equal(t, goclipper2.IsInside, goclipper2.PointInPathsD(sites[j], cell, goclipper2.NonZero))
+		assert.InDelta(t, 20000, goclipper2.AreaPathsD(cell), 1e-6)
+		total += goclipper2.AreaPathsD(cell)
+	}
+	assert.InDelta(t, goclipper2.AreaPathsD(clip), total, 1e-6)
+
+	// a region the bisector runs between splits the cell in two
+	split := goclipper2.PathsD{
+		goclipper2.MakePathD(0, 0, 100, 0, 100, 100, 0, 100),
+		goclipper2.MakePathD(200, 0, 300, 0, 300, 100, 200, 100),
+	}
+	v = goclipper2.VoronoiPathsD(goclipper2.MakePathD(150, 50, 150, 500), split, goclipper2.NonZero)
+	assert.Equal(t, 2, len(v.Cells[0]))
+	assert.Equal(t, 0, len(v.Cells[1]))
+}
+
+func TestDelaunayTriangulationD(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	var sites goclipper2.PathD
+	for i := 0; i < 200; i++ {
+		sites = append(sites, goclipper2.PointD{X: rnd.Float64() * 1000, Y: rnd.Float64() * 1000})
+	}
+
+	triangles := goclipper2.DelaunayTriangulationD(sites)
+
+	area := 0.0
+	paths := make(goclipper2.PathsD, 0, len(triangles))
+	for _, tri := range triangles {
+		a, b, c := sites[tri[0]], sites[tri[1]], sites[tri[2]]
+		path := goclipper2.PathD{a, b, c}
+		assert.True(t, goclipper2.IsPositiveD(path))
+		area += goclipper2.AreaD(path)
+		paths = append(paths, path)
+
+		// no site lies inside a triangle's circumcircle
+		bx, by, cx, cy := b.X-a.X, b.Y-a.Y, c.X-a.X, c.Y-a.Y
+		d := 2 * (bx*cy - by*cx)
+		ux := (cy*(bx*bx+by*by) - by*(cx*cx+cy*cy)) / d
+		uy := (bx*(cx*cx+cy*cy) - cx*(bx*bx+by*by)) / d
+		r := math.Hypot(ux, uy)
+		for _, pt := range sites {
+			assert.GreaterOrEqual(t, math.Hypot(pt.X-a.X-ux, pt.Y-a.Y-uy), r-1e-6)
+		}
+	}
+	// the triangles tile the hull without overlapping
+	hull := goclipper2.UnionPathsD(paths, goclipper2.NonZero, 6)
+	assert.Equal(t, 1, len(hull))
+	assert.InDelta(t, goclipper2.AreaPathsD(hull), area, 1e-3)
+	for _, pt := range sites {
+		assert.NotEqual(t, goclipper2.IsOutside, goclipper2.PointInPathsD(pt, hull, goclipper2.NonZero, 6))
+	}
+
+	assert.Equal(t, 0, len(goclipper2.DelaunayTriangulationD(goclipper2.MakePathD(0, 0, 1, 1, 2, 2, 3, 3))))
+	assert.Equal(t, 1, len(goclipper2.DelaunayTriangulationD(goclipper2.MakePathD(0, 0, 1, 0, 1, 0, 0, 1))))
+}